@@ -0,0 +1,26 @@
+// Package agent defines the agent interface for 3D ORCA, e.g. for aerial
+// agents. Agents are modeled as spheres.
+package agent
+
+import (
+	"github.com/downflux/go-geometry/3d/vector"
+)
+
+type A interface {
+	// P returns the current location of the agent.
+	P() vector.V
+
+	// V returns the current velocity vector of the agent.
+	V() vector.V
+
+	// R returns the radius of the bounding sphere of the agent.
+	R() float64
+
+	// T returns the target (read: preferred) velocity vector of the agent,
+	// e.g. a vector which points to the next waypoint node, with the
+	// maximum speed of the agent.
+	T() vector.V
+
+	// S returns the maximum speed of the agent.
+	S() float64
+}
@@ -0,0 +1,56 @@
+// Package acceleration defines acceleration-constrained agents.
+//
+// By default, go-orca assumes agents may change their velocity instantaneously,
+// and only bounds the output velocity by the maximum speed of the agent. This is
+// not a reasonable assumption for e.g. vehicles, which may take some time to
+// accelerate or brake. Agents may instead opt into acceleration-constrained
+// avoidance (AVO) by additionally implementing the acceleration.A interface.
+package acceleration
+
+import (
+	"github.com/downflux/go-orca/agent"
+)
+
+// A is an optional extension of the agent interface for agents with a maximum
+// acceleration.
+//
+// The set of velocities reachable by the agent within the next timestep is the
+// intersection of the maximum speed circle, and a circle centered at A.V() with
+// radius A.Accel() * A.DT().
+//
+// Per van den Berg et al. (2011), we assume the agent velocity changes linearly
+// over the timestep, rather than instantaneously; the velocity obstacles
+// generated for the agent are adjusted accordingly so that the collision-free
+// guarantee still holds.
+type A interface {
+	agent.A
+
+	// Accel returns the maximum acceleration of the agent.
+	Accel() float64
+
+	// DT returns the time between successive velocity updates of the
+	// agent, i.e. the length of time over which the agent may accelerate
+	// towards the new velocity.
+	DT() float64
+}
+
+// Unwrap returns the acceleration-constrained agent underlying the input agent.
+//
+// Agent wrappers, e.g. the enlarged agents used to construct NH-ORCA VOs, may
+// expose the underlying agent via an
+//
+//	Unwrap() agent.A
+//
+// method, in which case Unwrap will check the wrapped agent as well.
+func Unwrap(a agent.A) (A, bool) {
+	for {
+		if b, ok := a.(A); ok {
+			return b, true
+		}
+		w, ok := a.(interface{ Unwrap() agent.A })
+		if !ok {
+			return nil, false
+		}
+		a = w.Unwrap()
+	}
+}
@@ -0,0 +1,68 @@
+// Package bounds defines agents with non-circular velocity bounds.
+//
+// By default, go-orca bounds the output velocity of an agent by the maximum
+// speed circle of radius A.S(). This is not a reasonable model for e.g.
+// vehicles which may move quickly forward, but only slowly in reverse, and
+// which may strafe sideways at a limited speed, if at all. Agents may instead
+// supply an arbitrary convex velocity bound by additionally implementing the
+// bounds.A interface.
+package bounds
+
+import (
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/lp"
+)
+
+// A is an optional extension of the agent interface for agents with
+// non-circular velocity bounds.
+//
+// The caller is responsible for ensuring A.S() returns the maximum speed
+// admitted by A.B(), as A.S() is still used to e.g. determine the set of
+// neighbors which may collide with the agent.
+type A interface {
+	agent.A
+
+	// B returns the set of admissible velocities of the agent, in the
+	// world frame.
+	//
+	// The bound should contain the zero vector, as the infeasible fallback
+	// of the solver otherwise may not be able to bring the agent to a
+	// stop.
+	B() lp.M
+}
+
+// Polygon returns a convex polygonal velocity bound, where the vertices are
+// specified in the heading frame of the agent, i.e. the X-axis points along the
+// input heading h, and the Y-axis points to the left of the agent.
+func Polygon(h vector.V, vs []vector.V) (lp.M, error) {
+	ws := make([]vector.V, 0, len(vs))
+	for _, v := range vs {
+		ws = append(ws, global(h, v))
+	}
+	return lp.Polygon(ws)
+}
+
+// Ellipse returns an elliptical velocity bound oriented along the input heading
+// h. The agent may move forward along the heading at a maximum speed of
+// forward, and in reverse at a maximum speed of reverse. The lateral semi-axis
+// of the ellipse is lateral, i.e. if forward = reverse, the agent may move
+// sideways at a maximum speed of lateral.
+func Ellipse(h vector.V, forward float64, reverse float64, lateral float64) (lp.M, error) {
+	return lp.Ellipse(
+		global(h, *vector.New((forward-reverse)/2, 0)),
+		(forward+reverse)/2,
+		lateral,
+		h,
+	)
+}
+
+// global transforms the input vector in the heading frame into the world
+// frame.
+func global(h vector.V, v vector.V) vector.V {
+	h = vector.Unit(h)
+	return vector.Add(
+		vector.Scale(v.X(), h),
+		vector.Scale(v.Y(), *vector.New(-h.Y(), h.X())),
+	)
+}
@@ -0,0 +1,65 @@
+// Package footprint defines non-circular collision footprints for agents.
+//
+// By default, go-orca models each agent as a disc of radius A.R(). This
+// overestimates the footprint of long units, e.g. trains or vehicles with
+// trailers. Agents may instead opt into a convex footprint by additionally
+// implementing the footprint.A interface.
+package footprint
+
+import (
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/internal/geometry/2d/polygon"
+)
+
+// A is an optional extension of the agent interface for agents with a
+// non-circular footprint.
+//
+// Footprint-aware VOs are only constructed between two agents which both
+// implement A; otherwise, the default circular VO is used. The footprint-aware
+// VO is an ORCA VO, and orca.Step returns an error if two agents which
+// implement A interact under an HRVO construction mode.
+//
+// N.B.: A.R() is still used to determine the neighbor search radius, and
+// therefore must return the radius of a circle (centered on A.P()) which
+// bounds the footprint.
+type A interface {
+	agent.A
+
+	// F returns the footprint of the agent, relative to A.P(). The
+	// footprint is not rotated by go-orca -- callers are responsible for
+	// updating the footprint as the agent turns.
+	F() F
+}
+
+// F is a convex footprint, represented as a rounded convex polygon, i.e. the
+// Minkowski sum of a convex polygon and a disc.
+type F polygon.P
+
+// New constructs a footprint from the convex hull of the input vertices, with
+// corners rounded by the input radius.
+func New(vs []vector.V, r float64) *F {
+	f := F(*polygon.New(vs, r))
+	return &f
+}
+
+// NewCircle constructs a circular footprint centered on the agent.
+func NewCircle(r float64) *F { return New([]vector.V{*vector.New(0, 0)}, r) }
+
+// NewCapsule constructs a capsule footprint, i.e. the set of points within r
+// of the input line segment.
+func NewCapsule(s segment.S, r float64) *F {
+	return New([]vector.V{s.L().L(s.TMin()), s.L().L(s.TMax())}, r)
+}
+
+// NewPolygon constructs a footprint from the convex hull of the input
+// vertices.
+func NewPolygon(vs []vector.V) *F { return New(vs, 0) }
+
+// V returns the vertices of the underlying convex polygon of the footprint, in
+// counter-clockwise order.
+func (f F) V() []vector.V { return polygon.P(f).V() }
+
+// R returns the radius of the rounded corners of the footprint.
+func (f F) R() float64 { return polygon.P(f).R() }
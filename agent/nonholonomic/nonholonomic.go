@@ -0,0 +1,273 @@
+// Package nonholonomic defines agents which cannot move sideways, e.g.
+// differential-drive robots and car-like vehicles.
+//
+// ORCA calculates a holonomic velocity for each agent, i.e. a velocity which
+// may point in any direction, regardless of the current heading of the agent.
+// Per Alonso-Mora et al. (2010), a non-holonomic agent may track this
+// holonomic velocity by turning towards the holonomic velocity at a constant
+// angular velocity while moving forward; the agent will then deviate from the
+// holonomic trajectory by some bounded tracking error E. By enlarging the
+// effective radius of the agent by E, and by restricting the holonomic velocity
+// to the set of velocities which the agent may track within E (see B), the
+// collision-free guarantee of ORCA still holds for the non-holonomic agent
+// (NH-ORCA).
+//
+// Agents may opt into NH-ORCA by additionally implementing the nonholonomic.A
+// interface.
+package nonholonomic
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/lp"
+)
+
+const (
+	// n is the number of directions sampled when approximating the set
+	// of trackable velocities.
+	n = 32
+
+	// iterations is the number of bisection steps used to find the
+	// maximum trackable speed along each sampled direction.
+	iterations = 64
+)
+
+// A is an optional extension of the agent interface for non-holonomic agents.
+type A interface {
+	agent.A
+
+	// H returns the unit heading vector of the agent.
+	H() vector.V
+
+	// M returns the kinematic model of the agent.
+	M() M
+
+	// E returns the maximum tracking error of the agent, i.e. the maximum
+	// distance the agent may deviate from the holonomic trajectory
+	// calculated by ORCA. The effective radius of the agent is
+	// A.R() + A.E(), and the holonomic velocity is restricted to the set
+	// of velocities which M may track within this bound.
+	E() float64
+}
+
+// C is a velocity command for a non-holonomic agent.
+type C struct {
+	// V is the linear (forward) speed of the agent.
+	V float64
+
+	// W is the angular velocity of the agent, where a positive value
+	// indicates an anti-clockwise turn.
+	W float64
+}
+
+// M is a kinematic model which converts a holonomic velocity into a
+// non-holonomic command.
+type M interface {
+	// C returns the command which tracks the input holonomic velocity v,
+	// given the current unit heading h of the agent.
+	C(h vector.V, v vector.V) C
+
+	// E returns the tracking error incurred by the command C(h, v), i.e.
+	// the distance between the agent and the holonomic trajectory at the
+	// end of the turn.
+	E(h vector.V, v vector.V) float64
+}
+
+// B returns the set of holonomic velocities which the input agent may track
+// within its maximum tracking error A.E(), bounded by the maximum speed of the
+// agent.
+//
+// Per Alonso-Mora et al. (2010), the set of trackable velocities is
+// approximated by a convex polygon. Here, we find the maximum trackable speed
+// along a fixed set of directions, and take the largest convex polygon
+// contained within the (star-shaped) polygon spanned by these points.
+func B(a A) (lp.M, error) {
+	h, m, e := a.H(), a.M(), a.E()
+
+	vs := make([]vector.V, 0, n)
+	for i := 0; i < n; i++ {
+		theta := 2 * math.Pi * float64(i) / n
+		u := *vector.New(math.Cos(theta), math.Sin(theta))
+
+		// We assume the tracking error increases monotonically with
+		// the holonomic speed along a fixed direction.
+		lo, hi := 0.0, a.S()
+		if m.E(h, vector.Scale(hi, u)) > e {
+			for j := 0; j < iterations; j++ {
+				if s := (lo + hi) / 2; m.E(h, vector.Scale(s, u)) > e {
+					hi = s
+				} else {
+					lo = s
+				}
+			}
+			hi = lo
+		}
+		vs = append(vs, vector.Scale(hi, u))
+	}
+	return lp.Polygon(convex(vs))
+}
+
+// convex returns the largest convex polygon contained in the input polygon,
+// whose vertices are in counter-clockwise order around the origin, and which is
+// star-shaped with respect to the origin.
+//
+// Every point which lies on the origin side of each edge of the input polygon
+// lies within the polygon, as the ray from the origin to the point must exit
+// the polygon through one of the edges. We therefore clip the polygon against
+// the supporting line of each edge.
+func convex(vs []vector.V) []vector.V {
+	ws := vs
+	for i := range vs {
+		p, q := vs[i], vs[(i+1)%len(vs)]
+		d := vector.Sub(q, p)
+		if epsilon.Within(vector.Magnitude(d), 0) {
+			continue
+		}
+
+		// f returns the signed distance of the input vector from the
+		// supporting line of the edge, where points on the origin side
+		// of the edge are positive.
+		f := func(v vector.V) float64 { return vector.Determinant(d, vector.Sub(v, p)) }
+
+		var us []vector.V
+		for j := range ws {
+			a, b := ws[j], ws[(j+1)%len(ws)]
+			fa, fb := f(a), f(b)
+			if fa >= 0 {
+				us = append(us, a)
+			}
+			if (fa < 0) != (fb < 0) {
+				us = append(us, vector.Add(a, vector.Scale(fa/(fa-fb), vector.Sub(b, a))))
+			}
+		}
+		ws = us
+	}
+
+	// Remove duplicate and collinear vertices.
+	for {
+		var us []vector.V
+		for j := range ws {
+			a, b, c := ws[(j+len(ws)-1)%len(ws)], ws[j], ws[(j+1)%len(ws)]
+			if vector.Within(a, b) || epsilon.Within(vector.Determinant(vector.Sub(b, a), vector.Sub(c, b)), 0) {
+				continue
+			}
+			us = append(us, b)
+		}
+		if len(us) == len(ws) {
+			return us
+		}
+		ws = us
+	}
+}
+
+// DifferentialDrive is the kinematic model of a robot which may turn in place.
+type DifferentialDrive struct {
+	// T is the time the agent takes to turn towards the holonomic
+	// velocity.
+	T float64
+
+	// W is the maximum angular velocity of the agent.
+	W float64
+}
+
+func (m DifferentialDrive) C(h vector.V, v vector.V) C { return track(h, v, m.T, m.W) }
+
+func (m DifferentialDrive) E(h vector.V, v vector.V) float64 {
+	return e(h, v, m.C(h, v), m.T)
+}
+
+// Car is the kinematic model of a car-like vehicle with a minimum turning
+// radius, i.e. a bicycle model.
+//
+// N.B.: Unlike a differential-drive robot, a car cannot turn in place, and
+// will not reverse -- if the holonomic velocity lies directly behind the car,
+// the generated command will bring the car to a stop.
+type Car struct {
+	// T is the time the agent takes to turn towards the holonomic
+	// velocity.
+	T float64
+
+	// L is the wheelbase of the vehicle.
+	L float64
+
+	// Phi is the maximum steering angle of the vehicle, in radians.
+	Phi float64
+}
+
+func (m Car) C(h vector.V, v vector.V) C {
+	c := track(h, v, m.T, math.Inf(1))
+
+	// The turning rate of a car is bounded by its forward speed, i.e.
+	//
+	//   ω ≤ v tan(𝜙) / L
+	c.W = clamp(c.W, c.V*math.Tan(m.Phi)/m.L)
+	return c
+}
+
+func (m Car) E(h vector.V, v vector.V) float64 {
+	return e(h, v, m.C(h, v), m.T)
+}
+
+// track returns the command which turns the agent with heading h towards the
+// holonomic velocity v at a constant angular velocity (bounded by w) over time
+// t.
+//
+// Per Alonso-Mora et al. (2010), given the angle 𝜃 between h and v, the agent
+// turns at
+//
+//	ω = 𝜃 / t
+//
+// and the forward speed is chosen to minimize the tracking error, i.e.
+//
+//	V = ||v|| 𝜃 sin(𝜃) / (2 (1 - cos(𝜃)))
+//
+// which approaches ||v|| as 𝜃 approaches 0, and 0 as 𝜃 approaches ±π, i.e.
+// the agent turns in place if the holonomic velocity is directly behind it.
+//
+// If the angular velocity is clamped, the agent instead only turns through
+// 𝜙 = ωt, and the forward speed which minimizes the tracking error at time t is
+//
+//	V = ||v|| 𝜙 (sin(𝜃) - sin(𝜃 - 𝜙)) / (2 (1 - cos(𝜙)))
+//
+// which reduces to the above for 𝜙 = 𝜃. The agent does not reverse, i.e. V is
+// bounded below by 0.
+func track(h vector.V, v vector.V, t float64, w float64) C {
+	s := vector.Magnitude(v)
+	if epsilon.Within(s, 0) {
+		return C{}
+	}
+
+	theta := math.Atan2(vector.Determinant(h, v), vector.Dot(h, v))
+	if epsilon.Within(theta, 0) {
+		return C{V: s}
+	}
+
+	phi := clamp(theta, w*t)
+	return C{
+		V: math.Max(0, s*phi*(math.Sin(theta)-math.Sin(theta-phi))/(2*(1-math.Cos(phi)))),
+		W: phi / t,
+	}
+}
+
+// e returns the tracking error of the command c for an agent with heading h at
+// time t, relative to the holonomic velocity v.
+func e(h vector.V, v vector.V, c C, t float64) float64 {
+	// p is the position of the agent at time t, in the heading frame.
+	p := *vector.New(c.V*t, 0)
+	if !epsilon.Within(c.W, 0) {
+		p = vector.Scale(c.V/c.W, *vector.New(math.Sin(c.W*t), 1-math.Cos(c.W*t)))
+	}
+
+	// q is the position along the holonomic trajectory at time t, in the
+	// heading frame.
+	q := vector.Scale(t, *vector.New(vector.Dot(h, v), vector.Determinant(h, v)))
+	return vector.Magnitude(vector.Sub(p, q))
+}
+
+// clamp bounds the magnitude of the input value by the given limit.
+func clamp(x float64, limit float64) float64 {
+	return math.Max(-limit, math.Min(limit, x))
+}
@@ -0,0 +1,216 @@
+package nonholonomic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+)
+
+var (
+	_ M = DifferentialDrive{}
+	_ M = Car{}
+	_ A = a{}
+)
+
+// a is a non-holonomic agent.
+type a struct {
+	agentimpl.A
+	h vector.V
+	m M
+	e float64
+}
+
+func (a a) H() vector.V { return a.h }
+func (a a) M() M        { return a.m }
+func (a a) E() float64  { return a.e }
+
+func TestC(t *testing.T) {
+	type config struct {
+		name string
+		m    M
+		h    vector.V
+		v    vector.V
+		want C
+	}
+
+	testConfigs := []config{
+		{
+			name: "DifferentialDrive/Stop",
+			m:    DifferentialDrive{T: 1, W: 10},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(0, 0),
+			want: C{},
+		},
+		{
+			name: "DifferentialDrive/Forward",
+			m:    DifferentialDrive{T: 1, W: 10},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(2, 0),
+			want: C{V: 2},
+		},
+		// The holonomic velocity is perpendicular to the heading, i.e.
+		// 𝜃 = π / 2, and
+		//
+		//   V = ||v|| (π / 2) / 2
+		{
+			name: "DifferentialDrive/Left",
+			m:    DifferentialDrive{T: 1, W: 10},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(0, 1),
+			want: C{V: math.Pi / 4, W: math.Pi / 2},
+		},
+		// The agent may only turn through 𝜙 = -1 within T, and
+		//
+		//   V = ||v|| 𝜙 (sin(𝜃) - sin(𝜃 - 𝜙)) / (2 (1 - cos(𝜙)))
+		//     = (1 - cos(1)) / (2 (1 - cos(1)))
+		{
+			name: "DifferentialDrive/Right/MaxW",
+			m:    DifferentialDrive{T: 1, W: 1},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(0, -1),
+			want: C{V: 0.5, W: -1},
+		},
+		{
+			name: "DifferentialDrive/Behind",
+			m:    DifferentialDrive{T: 1, W: 10},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(-1, 0),
+			want: C{V: 0, W: math.Pi},
+		},
+		// The turning rate of the car is bounded by
+		//
+		//   ω ≤ V tan(π / 4) / 1 = π / 4
+		{
+			name: "Car/Left",
+			m:    Car{T: 1, L: 1, Phi: math.Pi / 4},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(0, 1),
+			want: C{V: math.Pi / 4, W: math.Pi / 4},
+		},
+		{
+			name: "Car/Behind",
+			m:    Car{T: 1, L: 1, Phi: math.Pi / 4},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(-1, 0),
+			want: C{},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got := c.m.C(c.h, c.v)
+			e := epsilon.Absolute(1e-10)
+			if !e.Within(got.V, c.want.V) || !e.Within(got.W, c.want.W) {
+				t.Errorf("C() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestE(t *testing.T) {
+	type config struct {
+		name string
+		m    M
+		h    vector.V
+		v    vector.V
+		want float64
+	}
+
+	testConfigs := []config{
+		{
+			name: "DifferentialDrive/Forward",
+			m:    DifferentialDrive{T: 1, W: 10},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(2, 0),
+			want: 0,
+		},
+		// Per Alonso-Mora et al. (2010), the tracking error of the
+		// optimal forward speed is
+		//
+		//   E = ||v|| t |sin(𝜃 / 2)|
+		{
+			name: "DifferentialDrive/Left",
+			m:    DifferentialDrive{T: 2, W: 10},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(0, 1),
+			want: 2 * math.Sin(math.Pi/4),
+		},
+		// The agent turns in place, and does not move towards the
+		// holonomic position at all.
+		{
+			name: "DifferentialDrive/Behind",
+			m:    DifferentialDrive{T: 1, W: 10},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(-1, 0),
+			want: 1,
+		},
+		{
+			name: "Car/Behind",
+			m:    Car{T: 1, L: 1, Phi: math.Pi / 4},
+			h:    *vector.New(1, 0),
+			v:    *vector.New(-1, 0),
+			want: 1,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.m.E(c.h, c.v); !epsilon.Within(got, c.want) {
+				t.Errorf("E() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestB checks that the trackable velocities of an agent may be tracked within
+// the maximum tracking error of the agent.
+func TestB(t *testing.T) {
+	type config struct {
+		name string
+		a    A
+	}
+
+	h := vector.Unit(*vector.New(1, 2))
+	testConfigs := []config{
+		{
+			name: "DifferentialDrive",
+			a:    a{A: *agentimpl.New(agentimpl.O{S: 10}), h: h, m: DifferentialDrive{T: 1, W: 10}, e: 1},
+		},
+		{
+			name: "DifferentialDrive/MaxW",
+			a:    a{A: *agentimpl.New(agentimpl.O{S: 10}), h: h, m: DifferentialDrive{T: 1, W: 1}, e: 0.5},
+		},
+		{
+			name: "Car",
+			a:    a{A: *agentimpl.New(agentimpl.O{S: 10}), h: h, m: Car{T: 1, L: 1, Phi: math.Pi / 4}, e: 0.5},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			m, err := B(c.a)
+			if err != nil {
+				t.Fatalf("B() encountered an unexpected error: %v", err)
+			}
+			if !m.In(*vector.New(0, 0)) {
+				t.Errorf("In() = false, want = true")
+			}
+
+			const n = 360
+			for i := 0; i < n; i++ {
+				theta := 2 * math.Pi * float64(i) / n
+				v := m.V(*vector.New(math.Cos(theta), math.Sin(theta)))
+				if got := c.a.M().E(c.a.H(), v); got > c.a.E()+1e-6 {
+					t.Errorf("E() = %v, want <= %v", got, c.a.E())
+				}
+				if got := vector.Magnitude(v); got > c.a.S()+1e-6 {
+					t.Errorf("||V()|| = %v, want <= %v", got, c.a.S())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package uncertainty defines agents with noisy state estimates.
+//
+// By default, go-orca trusts the values returned by A.P() and A.V(). In
+// practice, these are often estimates from noisy sensors, and the true state of
+// the agent may differ by some bounded amount. Agents may opt into
+// uncertainty-aware avoidance by additionally implementing the uncertainty.A
+// interface.
+//
+// Given an agent A and an obstacle B, suppose the true relative position lies
+// within 𝜀p := A.EP() + B.EP() of the estimate, and the true relative velocity
+// lies within 𝜀v := A.EV() + B.EV() of the estimate. The truncated VO is the
+// union of discs
+//
+//	VO = ⋃ { D(p / t, r / t) | 0 < t ≤ 𝜏 }
+//
+// where p and r are the relative position and combined radius of the agents.
+// Enlarging each disc by 𝜀p / t accounts for the position error, and enlarging
+// each disc by 𝜀v accounts for the velocity error. As
+//
+//	r / t + 𝜀p / t + 𝜀v = (r + 𝜀p + t𝜀v) / t ≤ (r + 𝜀p + 𝜏𝜀v) / t
+//
+// the VO constructed with the enlarged combined radius r + 𝜀p + 𝜏𝜀v contains
+// every VO consistent with the estimates, and the usual collision-free
+// guarantee holds under bounded uncertainty.
+package uncertainty
+
+import (
+	"math"
+
+	"github.com/downflux/go-orca/agent"
+)
+
+// A is an optional extension of the agent interface for agents with bounded
+// state estimation error.
+type A interface {
+	agent.A
+
+	// EP returns an upper bound on the distance between the true position
+	// of the agent and A.P().
+	EP() float64
+
+	// EV returns an upper bound on the magnitude of the difference between
+	// the true velocity of the agent and A.V().
+	EV() float64
+}
+
+// Bound returns the radius of the confidence disc of a 2D Gaussian error with
+// the input covariance matrix
+//
+//	[ xx xy ]
+//	[ xy yy ]
+//
+// The confidence ellipse of the error at k standard deviations has a major
+// semi-axis of k √𝜆, where 𝜆 is the largest eigenvalue of the covariance
+// matrix; the returned disc circumscribes this ellipse. For a 2D Gaussian, k = 3
+// corresponds to a confidence level of ~98.9%.
+//
+// Bound is useful for converting e.g. Kalman filter covariances into the
+// bounds reported by A.EP() and A.EV().
+func Bound(xx float64, xy float64, yy float64, k float64) float64 {
+	l := (xx+yy)/2 + math.Sqrt((xx-yy)*(xx-yy)/4+xy*xy)
+	return k * math.Sqrt(math.Max(0, l))
+}
+
+// R returns the amount by which the combined radius of the input agents must be
+// enlarged to account for their state estimation error over the lookahead time
+// 𝜏.
+//
+// Agents which do not implement A are assumed to have exact state estimates.
+// Agent wrappers may expose the underlying agent via an
+//
+//	Unwrap() agent.A
+//
+// method, in which case R will check the wrapped agent as well.
+func R(a agent.A, b agent.A, tau float64) float64 {
+	var r float64
+	for _, c := range []agent.A{a, b} {
+		if c, ok := unwrap(c); ok {
+			r += c.EP() + tau*c.EV()
+		}
+	}
+	return r
+}
+
+func unwrap(a agent.A) (A, bool) {
+	for {
+		if b, ok := a.(A); ok {
+			return b, true
+		}
+		w, ok := a.(interface{ Unwrap() agent.A })
+		if !ok {
+			return nil, false
+		}
+		a = w.Unwrap()
+	}
+}
@@ -0,0 +1,87 @@
+package uncertainty
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/agent"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+)
+
+var _ A = u{}
+
+// u is an agent with bounded state estimation error.
+type u struct {
+	agentimpl.A
+	ep float64
+	ev float64
+}
+
+func (u u) EP() float64 { return u.ep }
+func (u u) EV() float64 { return u.ev }
+
+// w is an agent wrapper which hides the uncertainty.A interface of the
+// underlying agent.
+type w struct {
+	agent.A
+}
+
+func (w w) Unwrap() agent.A { return w.A }
+
+func TestBound(t *testing.T) {
+	type config struct {
+		name string
+		xx   float64
+		xy   float64
+		yy   float64
+		k    float64
+		want float64
+	}
+
+	testConfigs := []config{
+		{name: "Zero", xx: 0, xy: 0, yy: 0, k: 3, want: 0},
+		{name: "Isotropic", xx: 4, xy: 0, yy: 4, k: 3, want: 6},
+		{name: "Axis", xx: 1, xy: 0, yy: 9, k: 1, want: 3},
+		// The covariance matrix [[2, 1], [1, 2]] has eigenvalues 1 and
+		// 3.
+		{name: "Correlated", xx: 2, xy: 1, yy: 2, k: 2, want: 2 * 1.7320508075688772},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Bound(c.xx, c.xy, c.yy, c.k); !epsilon.Within(got, c.want) {
+				t.Errorf("Bound() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestR(t *testing.T) {
+	a := *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 0), R: 1})
+
+	type config struct {
+		name string
+		a    agent.A
+		b    agent.A
+		tau  float64
+		want float64
+	}
+
+	testConfigs := []config{
+		{name: "Exact", a: a, b: a, tau: 2, want: 0},
+		{name: "Single", a: u{A: a, ep: 1, ev: 0.5}, b: a, tau: 2, want: 2},
+		{name: "Both", a: u{A: a, ep: 1, ev: 0.5}, b: u{A: a, ep: 1}, tau: 2, want: 3},
+		{name: "Unwrap", a: w{A: w{A: u{A: a, ep: 1, ev: 0.5}}}, b: a, tau: 2, want: 2},
+		{name: "Unwrap/Exact", a: w{A: a}, b: a, tau: 2, want: 0},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := R(c.a, c.b, c.tau); !epsilon.Within(got, c.want) {
+				t.Errorf("R() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Package compare runs a scenario through both the ORCA implementation in this
+// library and the transpiled RVO2 simulator in external/snape/RVO2/simulator,
+// and reports the per-agent trajectory divergence between the two over time.
+//
+// The scenario parameters are mapped onto RVO2 as follows:
+//
+//   - both the agent and obstacle time horizons are set to the scenario Tau,
+//   - the neighbor distance of each agent is Tau * S + 2 * R, which matches the
+//     neighbor search radius of orca.Step, and the number of neighbors is
+//     unbounded,
+//   - the preferred velocity of each agent is recalculated every tick from the
+//     agent goal, as in the demo app, and
+//   - walls, including each segment of a region, are added as two-vertex (i.e.
+//     double-sided) obstacles.
+//
+// Both simulations call ORCA every tick, regardless of the scenario ORCA
+// interval.
+//
+// Known sources of divergence include existing agent-agent collisions, which
+// RVO2 resolves within a single simulation tick, whereas orca.Step resolves
+// them over a fixed short time horizon; and walls, for which orca.Step
+// constructs a different VO than the RVO2 polygonal obstacle VO, and which RVO2
+// only considers within the obstacle time horizon of the agent. Walls with a
+// non-zero thickness are not supported by RVO2.
+package compare
+
+import (
+	"github.com/downflux/go-orca/examples/scenario"
+	"github.com/downflux/go-orca/examples/simulation"
+	"github.com/downflux/go-orca/external/snape/RVO2/simulator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v2d "github.com/downflux/go-geometry/2d/vector"
+	exampleagent "github.com/downflux/go-orca/examples/agent"
+)
+
+// O is an options struct passed into Compare.
+type O struct {
+	// S is the scenario to simulate. The simulation parameters of the
+	// scenario, i.e. the lookahead time, framerate, and number of ticks,
+	// are used for both simulations.
+	S scenario.O
+}
+
+// D is the divergence between the two simulations of a single agent at a
+// single tick.
+type D struct {
+	// Tick is the simulation tick, starting from 1 after the first step.
+	Tick int
+
+	// T is the simulation time at the end of the tick.
+	T float64
+
+	// Agent is the index of the agent in the input scenario.
+	Agent int
+
+	// P is the distance between the positions of the agent in the two
+	// simulations.
+	P float64
+
+	// V is the magnitude of the difference between the velocities of the
+	// agent in the two simulations.
+	V float64
+}
+
+// Compare steps through both simulations in lockstep, and returns the
+// divergence of every agent after every tick, ordered by tick and then by
+// agent.
+func Compare(o O) ([]D, error) {
+	if err := scenario.Validate(o.S); err != nil {
+		return nil, err
+	}
+	for i, w := range o.S.Walls {
+		if w.W != 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "wall %v has a non-zero thickness %v, which is not supported by RVO2", i, w.W)
+		}
+	}
+	for i, r := range o.S.Regions {
+		if r.W != 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "region %v has a non-zero thickness %v, which is not supported by RVO2", i, r.W)
+		}
+	}
+
+	dt := o.S.DT()
+	tau := o.S.Sim.Tau
+
+	s, err := simulation.New(simulation.O{
+		Agents:       o.S.Agents,
+		R:            o.S.R(),
+		DT:           dt,
+		ORCAInterval: 1,
+		Tau:          tau,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// ms mirror the agents in the RVO2 simulation, and are used to
+	// calculate the preferred velocities of the RVO2 agents.
+	ms := make([]*exampleagent.A, 0, len(o.S.Agents))
+
+	sim := simulator.New(dt)
+	for _, c := range o.S.Agents {
+		m := exampleagent.New(c)
+		ms = append(ms, m)
+		sim.AddAgent(simulator.O{
+			P:               m.P(),
+			V:               m.V(),
+			R:               m.R(),
+			S:               m.S(),
+			NeighborDist:    tau*m.S() + 2*m.R(),
+			MaxNeighbors:    len(o.S.Agents),
+			TimeHorizon:     tau,
+			TimeHorizonObst: tau,
+		})
+	}
+	for i, r := range s.Segments() {
+		for _, t := range r.R() {
+			if _, err := sim.AddObstacle([]v2d.V{
+				t.L().L(t.TMin()),
+				t.L().L(t.TMax()),
+			}); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "cannot add region %v: %v", i, err)
+			}
+		}
+	}
+
+	ds := make([]D, 0, o.S.Sim.Ticks*len(ms))
+	for i := 1; i <= o.S.Sim.Ticks; i++ {
+		if err := s.Step(); err != nil {
+			return nil, err
+		}
+
+		for j, m := range ms {
+			sim.SetT(j, m.T())
+		}
+		sim.Step()
+
+		for j, a := range s.Agents() {
+			b := sim.Agent(j)
+
+			ms[j].SetP(b.P())
+			ms[j].SetV(b.V())
+
+			ds = append(ds, D{
+				Tick:  i,
+				T:     sim.T(),
+				Agent: j,
+				P:     v2d.Magnitude(v2d.Sub(a.P(), b.P())),
+				V:     v2d.Magnitude(v2d.Sub(a.V(), b.V())),
+			})
+		}
+	}
+
+	return ds, nil
+}
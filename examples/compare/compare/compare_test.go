@@ -0,0 +1,100 @@
+package compare
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/examples/agent"
+	"github.com/downflux/go-orca/examples/scenario"
+	"github.com/downflux/go-orca/examples/segment"
+
+	exampleconfig "github.com/downflux/go-orca/examples/config"
+)
+
+func TestCompare(t *testing.T) {
+	type config struct {
+		name string
+		c    exampleconfig.O
+		succ bool
+
+		// tolerance is the maximum expected position divergence of any
+		// agent over the simulation.
+		tolerance float64
+	}
+
+	testConfigs := []config{
+		{
+			name: "Free",
+			c: exampleconfig.O{
+				Agents: []agent.O{
+					{P: *vector.New(0, 0), G: *vector.New(50, 50), S: 10, R: 1},
+					{P: *vector.New(100, 0), G: *vector.New(50, -50), S: 10, R: 1},
+				},
+			},
+			succ:      true,
+			tolerance: 1e-10,
+		},
+		{
+			name: "HeadOn",
+			c: exampleconfig.O{
+				Agents: []agent.O{
+					{P: *vector.New(0, 0.1), G: *vector.New(20, 0.1), S: 10, R: 1},
+					{P: *vector.New(20, -0.1), G: *vector.New(0, -0.1), S: 10, R: 1},
+				},
+			},
+			succ:      true,
+			tolerance: 1,
+		},
+		{
+			name: "Wall/Parallel",
+			c: exampleconfig.O{
+				Agents: []agent.O{
+					{P: *vector.New(0, 0), G: *vector.New(20, 0), S: 10, R: 1},
+				},
+				Segments: []segment.O{
+					{P: *vector.New(-5, 3), D: *vector.New(1, 0), TMin: 0, TMax: 30},
+				},
+			},
+			succ:      true,
+			tolerance: 1e-10,
+		},
+		{
+			name: "Wall/Thick",
+			c: exampleconfig.O{
+				Agents: []agent.O{
+					{P: *vector.New(0, 0), G: *vector.New(0, 20), S: 10, R: 1},
+				},
+				Segments: []segment.O{
+					{P: *vector.New(-5, 5), D: *vector.New(1, 0), TMin: 0, TMax: 10, W: 1},
+				},
+			},
+			succ: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			// The migrated scenario runs for 120 ticks at 60 Hz, with
+			// a lookahead time of 0.9s.
+			sc := scenario.Migrate(c.c)
+
+			ds, err := Compare(O{S: sc})
+			if succ := err == nil; succ != c.succ {
+				t.Fatalf("Compare() returned error %v, want success = %v", err, c.succ)
+			}
+			if !c.succ {
+				return
+			}
+
+			if got, want := len(ds), sc.Sim.Ticks*len(c.c.Agents); got != want {
+				t.Fatalf("len(Compare()) = %v, want = %v", got, want)
+			}
+			for _, d := range ds {
+				if math.IsNaN(d.P) || d.P > c.tolerance {
+					t.Errorf("agent %v diverged by %v at tick %v, want <= %v", d.Agent, d.P, d.Tick, c.tolerance)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package main defines a small CLI app which runs an agent layout through both
+// the ORCA implementation in this library and the transpiled RVO2 simulator,
+// and reports the per-agent trajectory divergence between the two.
+//
+// The app reads either a versioned scenario or a legacy demo config; see the
+// scenario package. The simulation parameters of the scenario may be
+// overridden via flags.
+//
+// The app prints the divergence of every agent at every tick to stdout in CSV
+// format, with columns
+//
+//	tick, t, agent, p, v
+//
+// where p and v are the distances between the positions and velocities of the
+// agent in the two simulations. A per-agent summary of the maximum position
+// divergence is logged to stderr.
+//
+// Example:
+//
+//	go run \
+//	  examples/generator/main.go --mode=collision | go run \
+//	  examples/compare/main.go > divergence.csv
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/downflux/go-orca/examples/compare/compare"
+	"github.com/downflux/go-orca/examples/scenario"
+)
+
+var (
+	out       = flag.String("o", "/dev/stdout", "output file path, e.g. path/to/divergence.csv")
+	in        = flag.String("i", "/dev/stdin", "input file path, e.g. path/to/config.json")
+	frames    = flag.Int("frames", 0, "number of simulation ticks; overrides the scenario value if set")
+	framerate = flag.Float64("framerate", 0, "number of simulation ticks per second; overrides the scenario value if set")
+	tau       = flag.Float64("tau", 0, "lookahead time passed into both simulations; overrides the scenario value if set")
+)
+
+func main() {
+	flag.Parse()
+
+	r, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("cannot open file %v: %v", *in, err)
+	}
+	data, err := bufio.NewReader(r).ReadBytes(byte(0))
+	if err != io.EOF {
+		log.Fatalf("could not read from file %v: %v", *in, err)
+	}
+
+	sc, err := scenario.Load(data)
+	if err != nil {
+		log.Fatalf("cannot load scenario %v: %v", *in, err)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "frames":
+			sc.Sim.Ticks = *frames
+		case "framerate":
+			sc.Sim.Framerate = *framerate
+		case "tau":
+			sc.Sim.Tau = *tau
+		}
+	})
+
+	ds, err := compare.Compare(compare.O{S: sc})
+	if err != nil {
+		log.Fatalf("cannot compare simulations: %v", err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("cannot write to file %v: %v", *out, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"tick", "t", "agent", "p", "v"})
+
+	// ms tracks the maximum position divergence of each agent, and the
+	// tick at which it occurs.
+	type max struct {
+		p    float64
+		tick int
+	}
+	var ms []max
+
+	for _, d := range ds {
+		w.Write([]string{
+			strconv.Itoa(d.Tick),
+			strconv.FormatFloat(d.T, 'g', -1, 64),
+			strconv.Itoa(d.Agent),
+			strconv.FormatFloat(d.P, 'g', -1, 64),
+			strconv.FormatFloat(d.V, 'g', -1, 64),
+		})
+
+		for len(ms) <= d.Agent {
+			ms = append(ms, max{})
+		}
+		if ms[d.Agent].tick == 0 || d.P > ms[d.Agent].p {
+			ms[d.Agent] = max{p: d.P, tick: d.Tick}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Fatalf("cannot write to file %v: %v", *out, err)
+	}
+
+	for i, m := range ms {
+		log.Printf("agent %v diverged by at most %v at tick %v", i, m.p, m.tick)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/downflux/go-orca/examples/segment"
 )
 
+// O is the legacy unversioned demo layout. New layouts should use the versioned
+// scenario format in examples/scenario, which migrates O on load.
 type O struct {
 	Agents   []agent.O
 	Segments []segment.O
@@ -0,0 +1,120 @@
+// Package main defines a small CLI app which runs an agent layout through ORCA
+// without rendering, and outputs the per-tick agent positions and velocities
+// for offline analysis.
+//
+// The app reads either a versioned scenario or a legacy demo config; see the
+// scenario package. The simulation parameters of the scenario may be
+// overridden via flags.
+//
+// The app writes one record per agent per tick, including the initial state at
+// tick 0, as either JSON lines or CSV; see the trajectory package for the
+// record format.
+//
+// Example:
+//
+//	go run \
+//	  examples/generator/main.go --mode=random | go run \
+//	  examples/headless/main.go --format=csv > trajectory.csv
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/downflux/go-orca/examples/scenario"
+	"github.com/downflux/go-orca/examples/simulation"
+	"github.com/downflux/go-orca/examples/trajectory"
+	"github.com/downflux/go-orca/orca/mode"
+)
+
+var (
+	out      = flag.String("o", "/dev/stdout", "output file path, e.g. path/to/trajectory.jsonl")
+	in       = flag.String("i", "/dev/stdin", "input file path, e.g. path/to/config.json")
+	format   = flag.String("format", "jsonl", "output format, must be one of (jsonl | csv)")
+	ticks    = flag.Int("ticks", 0, "number of simulation ticks; overrides the scenario value if set")
+	dt       = flag.Float64("dt", 0, "duration of a single simulation tick, in seconds; overrides the scenario framerate if set")
+	interval = flag.Int("interval", 0, "number of ticks in between ORCA calls; overrides the scenario value if set")
+	tau      = flag.Float64("tau", 0, "lookahead time passed into ORCA; overrides the scenario value if set")
+	hrvo     = flag.Bool("hrvo", false, "use the hybrid reciprocal VO construction for agent-agent interactions")
+)
+
+func main() {
+	flag.Parse()
+
+	r, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("cannot open file %v: %v", *in, err)
+	}
+	data, err := bufio.NewReader(r).ReadBytes(byte(0))
+	if err != io.EOF {
+		log.Fatalf("could not read from file %v: %v", *in, err)
+	}
+
+	sc, err := scenario.Load(data)
+	if err != nil {
+		log.Fatalf("cannot load scenario %v: %v", *in, err)
+	}
+
+	o := simulation.O{
+		Agents:       sc.Agents,
+		R:            sc.R(),
+		DT:           sc.DT(),
+		ORCAInterval: sc.Sim.ORCAInterval,
+		Tau:          sc.Sim.Tau,
+		Mode:         mode.ORCA,
+	}
+	n := sc.Sim.Ticks
+
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "ticks":
+			n = *ticks
+		case "dt":
+			o.DT = *dt
+		case "interval":
+			o.ORCAInterval = *interval
+		case "tau":
+			o.Tau = *tau
+		}
+	})
+	if *hrvo {
+		o.Mode = mode.HRVO
+	}
+
+	s, err := simulation.New(o)
+	if err != nil {
+		log.Fatalf("cannot create simulation: %v", err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("cannot write to file %v: %v", *out, err)
+	}
+	defer f.Close()
+
+	b := bufio.NewWriter(f)
+	w, err := trajectory.New(b, trajectory.F(*format))
+	if err != nil {
+		log.Fatalf("cannot create trajectory writer: %v", err)
+	}
+
+	rs, err := s.Run(n)
+	if err != nil {
+		log.Fatalf("error while stepping through the simulation: %v", err)
+	}
+	for _, r := range rs {
+		if err := w.Write(r); err != nil {
+			log.Fatalf("cannot write to file %v: %v", *out, err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		log.Fatalf("cannot write to file %v: %v", *out, err)
+	}
+	if err := b.Flush(); err != nil {
+		log.Fatalf("cannot write to file %v: %v", *out, err)
+	}
+}
@@ -1,6 +1,9 @@
 // Package main executes a short demo of ORCA and outputs a gif of the
 // calculated trajectory of the set of agents.
 //
+// The app reads either a versioned scenario or a legacy demo config; see the
+// scenario package. The simulation is run with the parameters of the scenario.
+//
 // Example:
 //
 //	go run \
@@ -17,57 +20,19 @@ import (
 	"io"
 	"log"
 	"math"
-	"math/rand"
 	"os"
-	"runtime"
 
 	"github.com/downflux/go-geometry/2d/line"
 	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/nd/hyperrectangle"
 	"github.com/downflux/go-geometry/nd/vector"
-	"github.com/downflux/go-kd/kd"
-	"github.com/downflux/go-kd/point"
-	"github.com/downflux/go-orca/agent"
-	"github.com/downflux/go-orca/examples/config"
-	"github.com/downflux/go-orca/orca"
-	"github.com/downflux/go-orca/region"
+	"github.com/downflux/go-orca/examples/scenario"
+	"github.com/downflux/go-orca/examples/simulation"
+	"github.com/downflux/go-orca/orca/mode"
+	"github.com/downflux/go-orca/orca/stall"
 
 	v2d "github.com/downflux/go-geometry/2d/vector"
-	exampleagent "github.com/downflux/go-orca/examples/agent"
 	examplesdraw "github.com/downflux/go-orca/examples/draw"
-	examplesegment "github.com/downflux/go-orca/examples/segment"
-)
-
-const (
-	Framerate = 60
-
-	// ORCAInterval dictates how many frames to skip before calling ORCA.
-	//
-	// Note that this linearly correlates with time.
-	ORCAInterval = 1
-)
-
-var (
-	// Tau is a time interval passed into the ORCA simulation; this scalar
-	// is used in conjunction with the agent speed to help determine an
-	// event horizon for the simulated agents. Agents beyond this event
-	// horizon are not considered for collision detection.
-	//
-	// If a neighbor of an agent is moving at a much greater max speed, it
-	// is possible with a small enough event horizon for a collision event
-	// to occur. In order to guarantee these types of collisions do not
-	// occur, we need to set the event horizon radius to the maximum
-	// traversable distance in between ORCA steps, but in reality we can set
-	// the actual event horizon radius to be a bit smaller than this.
-	//
-	// The time in between ORCA steps can be calculated via
-	//
-	//   ORCAInterval / Framerate
-	//
-	// If we set 𝜏 too small however, agent-agent collision detection occurs
-	// at the very last moment, which will result in unnatural simulations.
-	// The value here was determined experimentally to look good.
-	Tau = (0.9 * Framerate) * float64(ORCAInterval) / Framerate
 )
 
 var (
@@ -84,59 +49,21 @@ var (
 
 	out    = flag.String("o", "/dev/stdout", "output file path, e.g. path/to/output.gif")
 	in     = flag.String("i", "/dev/stdin", "input file path, e.g. path/to/config.json")
-	frames = flag.Int("frames", 120, "number of frames to render")
-
-	// Interface checks to demonstrate the functionality P is fulfilling in
-	// the demo.
-	_ point.P = &P{}
+	frames = flag.Int("frames", 0, "number of frames to render; overrides the scenario number of ticks if set")
+	hrvo   = flag.Bool("hrvo", false, "use the hybrid reciprocal VO construction for agent-agent interactions")
+	stalls = flag.Bool("stalls", false, "log clusters of stalled agents to stderr at the end of the simulation")
 
 	// margin is the minimum size of the rectangle drawn on screen.
 	margin = *v2d.New(50, 50)
 )
 
-type P exampleagent.A
-
-func (p *P) A() agent.A  { return (*exampleagent.A)(p) }
-func (p *P) P() vector.V { return vector.V((*exampleagent.A)(p).P()) }
-
-func rn(min float64, max float64) float64 { return rand.Float64()*(max-min) + min }
-
-type Env struct {
-	ps []*P
-	ss []region.R
-}
-
-func New(data []byte) *Env {
-	c := config.Unmarshal(data)
-	points := make([]*P, 0, len(c.Agents))
-	segments := make([]region.R, 0, len(c.Segments))
-
-	for _, o := range c.Agents {
-		a := *exampleagent.New(o)
-		p := P(a)
-		points = append(points, &p)
-	}
-
-	for _, o := range c.Segments {
-		segments = append(segments, *examplesegment.New(o))
-	}
-
-	return &Env{
-		ps: points,
-		ss: segments,
-	}
-}
-
-func (e *Env) Points() []*P         { return e.ps }
-func (e *Env) Segments() []region.R { return e.ss }
-
 // bound calculates the bounding rectangle around all agents.
-func bound(e *Env) hyperrectangle.R {
+func bound(s *simulation.S) hyperrectangle.R {
 	min := *v2d.New(math.Inf(0), math.Inf(0))
 	max := *v2d.New(math.Inf(-1), math.Inf(-1))
 
-	for _, p := range e.Points() {
-		p := v2d.V(p.P())
+	for _, a := range s.Agents() {
+		p := a.P()
 		min = *v2d.New(
 			math.Min(min.X(), p.X()),
 			math.Min(min.Y(), p.Y()),
@@ -147,7 +74,7 @@ func bound(e *Env) hyperrectangle.R {
 		)
 	}
 
-	for _, r := range e.Segments() {
+	for _, r := range s.Segments() {
 		for _, s := range r.R() {
 			min = *v2d.New(
 				math.Min(
@@ -207,33 +134,56 @@ func main() {
 	if err != io.EOF {
 		log.Fatalf("could not read from file %v: %v", *in, err)
 	}
-	env := New(data)
+	sc, err := scenario.Load(data)
+	if err != nil {
+		log.Fatalf("cannot load scenario %v: %v", *in, err)
+	}
+	n := sc.Sim.Ticks
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "frames" {
+			n = *frames
+		}
+	})
 
-	// Construct a new K-D tree for neighbor queries. Note the scope of this
-	// variable -- in real applications, this tree is very useful for
-	// operations not strictly limited to ORCA, e.g. for calculating nearest
-	// neighbors for fog-of-war calculations.
-	tr := kd.New(kd.O[*P]{
-		Data: env.Points(),
-		K:    2,
-		N:    16,
+	m := mode.ORCA
+	if *hrvo {
+		m = mode.HRVO
+	}
+	s, err := simulation.New(simulation.O{
+		Agents:       sc.Agents,
+		R:            sc.R(),
+		DT:           sc.DT(),
+		ORCAInterval: sc.Sim.ORCAInterval,
+		Tau:          sc.Sim.Tau,
+		Mode:         m,
 	})
+	if err != nil {
+		log.Fatalf("cannot create simulation: %v", err)
+	}
 
 	var images []*image.Paletted
 	var delay []int
 
-	b := bound(env)
+	b := bound(s)
 
 	// trailbuf keeps the last N positions of agents in memory for
 	// visualization.
 	var trailbuf [50][]v2d.V
 
+	// d tracks agents which have made little progress towards their goals
+	// over the last second of simulation.
+	d := stall.New(stall.O{
+		W: int(math.Max(1, sc.Sim.Framerate/float64(sc.Sim.ORCAInterval))),
+		S: 0.9,
+		R: 1,
+	})
+
 	// Run the simulator for some steps.
-	for i := 0; i < *frames; i++ {
+	for i := 0; i < n; i++ {
 		// Overwrite trail buffer
 		trailbuf[i%len(trailbuf)] = nil
-		for _, p := range env.Points() {
-			trailbuf[i%len(trailbuf)] = append(trailbuf[i%len(trailbuf)], p.A().P())
+		for _, a := range s.Agents() {
+			trailbuf[i%len(trailbuf)] = append(trailbuf[i%len(trailbuf)], a.P())
 		}
 
 		img := image.NewPaletted(
@@ -263,9 +213,7 @@ func main() {
 		}
 
 		// Draw agents.
-		for _, p := range env.Points() {
-			a := p.A().(*exampleagent.A)
-
+		for _, a := range s.Agents() {
 			// Draw agent goal positions.
 			examplesdraw.Circle(img, v2d.Add(margin, a.G()), 2, green)
 
@@ -274,7 +222,7 @@ func main() {
 		}
 
 		// Draw lines.
-		for _, r := range env.Segments() {
+		for _, r := range s.Segments() {
 			for _, s := range r.R() {
 				examplesdraw.Line(
 					img,
@@ -292,45 +240,27 @@ func main() {
 		}
 
 		// ORCA may be run at a slower rate than the tick rate.
-		if i%ORCAInterval == 0 {
-			res, err := orca.Step(orca.O[*P]{
-				T:   tr,
-				R:   env.Segments(),
-				Tau: Tau,
-				F:   func(a agent.A) bool { return true },
-				// We found this is the fastest configuration
-				// via benchmarking.
-				PoolSize: 4 * runtime.GOMAXPROCS(0),
-			})
-			if err != nil {
-				log.Fatalf("error while stepping through ORCA: %v", err)
-			}
-			for _, m := range res {
-				a := m.A.(*exampleagent.A)
-				a.SetV(m.V)
-			}
+		called := s.Tick()%sc.Sim.ORCAInterval == 0
+		if err := s.Step(); err != nil {
+			log.Fatalf("error while stepping through the simulation: %v", err)
 		}
-
-		// Run simulation for the current server tick.
-		for _, p := range env.Points() {
-			a := p.A().(*exampleagent.A)
-			a.SetP(
-				v2d.Add(
-					a.P(),
-					v2d.Scale(1/float64(Framerate), a.V()),
-				),
-			)
+		if called {
+			d.Add(s.Mutations())
 		}
 
 		// Render with approximately 2/100 s delay, i.e. at 50Hz.
 		images = append(images, img)
 		delay = append(delay, 2)
+	}
 
-		// Update the K-D tree, as positions have changed in the
-		// interim. Note that the K-D tree is mutated outside of calling
-		// ORCA -- this allows the tree to be used elsewhere, as it's a
-		// very useful struct for other range queries.
-		tr.Balance()
+	if *stalls {
+		for _, c := range d.Clusters() {
+			var ps []v2d.V
+			for _, a := range c {
+				ps = append(ps, a.P())
+			}
+			log.Printf("found a cluster of %v stalled agents at %v", len(c), ps)
+		}
 	}
 
 	// Export animation as a gif.
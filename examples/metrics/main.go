@@ -0,0 +1,116 @@
+// Package main defines a small CLI app which computes quality metrics of a
+// simulation run, e.g. the number of collisions, or the time taken for each
+// agent to reach its goal.
+//
+// The app reads the scenario of the run, and either the recorded trajectory of
+// the run as emitted by the headless simulation app, or, if no trajectory is
+// specified, simulates the scenario directly. The metrics are printed to stdout
+// in JSON format; see the metrics package for the list of metrics.
+//
+// Example:
+//
+//	go run \
+//	  examples/generator/main.go --mode=random > config.json
+//	go run \
+//	  examples/headless/main.go -i config.json > trajectory.jsonl
+//	go run \
+//	  examples/metrics/main.go -i config.json -t trajectory.jsonl
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/downflux/go-orca/examples/metrics/metrics"
+	"github.com/downflux/go-orca/examples/scenario"
+	"github.com/downflux/go-orca/examples/simulation"
+	"github.com/downflux/go-orca/examples/trajectory"
+	"github.com/downflux/go-orca/orca/mode"
+)
+
+var (
+	out       = flag.String("o", "/dev/stdout", "output file path, e.g. path/to/metrics.json")
+	in        = flag.String("i", "/dev/stdin", "scenario file path, e.g. path/to/config.json")
+	tr        = flag.String("t", "", "trajectory file path, e.g. path/to/trajectory.jsonl; if unset, the scenario is simulated directly")
+	format    = flag.String("format", "jsonl", "trajectory file format, must be one of (jsonl | csv)")
+	tolerance = flag.Float64("tolerance", 1, "maximum distance from its goal at which an agent is considered to have arrived")
+	window    = flag.Float64("stall-window", 1, "duration of the sliding window of the stall detector, in seconds")
+	threshold = flag.Float64("stall-threshold", 0.9, "mean velocity shortfall above which an agent is considered stalled")
+)
+
+func read(fn string) []byte {
+	r, err := os.Open(fn)
+	if err != nil {
+		log.Fatalf("cannot open file %v: %v", fn, err)
+	}
+	data, err := bufio.NewReader(r).ReadBytes(byte(0))
+	if err != io.EOF {
+		log.Fatalf("could not read from file %v: %v", fn, err)
+	}
+	return data
+}
+
+func main() {
+	flag.Parse()
+
+	sc, err := scenario.Load(read(*in))
+	if err != nil {
+		log.Fatalf("cannot load scenario %v: %v", *in, err)
+	}
+
+	var rs []trajectory.R
+	if *tr == "" {
+		s, err := simulation.New(simulation.O{
+			Agents:       sc.Agents,
+			R:            sc.R(),
+			DT:           sc.DT(),
+			ORCAInterval: sc.Sim.ORCAInterval,
+			Tau:          sc.Sim.Tau,
+			Mode:         mode.ORCA,
+		})
+		if err != nil {
+			log.Fatalf("cannot create simulation: %v", err)
+		}
+		if rs, err = s.Run(sc.Sim.Ticks); err != nil {
+			log.Fatalf("error while stepping through the simulation: %v", err)
+		}
+	} else {
+		f, err := os.Open(*tr)
+		if err != nil {
+			log.Fatalf("cannot open file %v: %v", *tr, err)
+		}
+		if rs, err = trajectory.Read(bufio.NewReader(f), trajectory.F(*format)); err != nil {
+			log.Fatalf("cannot read trajectory %v: %v", *tr, err)
+		}
+	}
+
+	m, err := metrics.Compute(metrics.O{
+		Agents:         sc.Agents,
+		R:              sc.R(),
+		Tolerance:      *tolerance,
+		StallWindow:    *window,
+		StallThreshold: *threshold,
+	}, rs)
+	if err != nil {
+		log.Fatalf("cannot compute metrics: %v", err)
+	}
+
+	b, err := json.MarshalIndent(m, "", " ")
+	if err != nil {
+		log.Fatalf("cannot export metrics: %v", err)
+	}
+
+	w, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("cannot write to file %v: %v", *out, err)
+	}
+	defer w.Close()
+
+	if _, err := w.Write(append(b, '\n')); err != nil {
+		log.Fatalf("cannot write to file %v: %v", *out, err)
+	}
+}
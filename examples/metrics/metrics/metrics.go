@@ -0,0 +1,335 @@
+// Package metrics computes quality metrics of a recorded simulation run, e.g.
+// as emitted by the headless simulation app, which may be used to compare the
+// effects of simulation parameter changes.
+//
+// Goal-related metrics are computed against the agent goals G of the input
+// agent layout.
+package metrics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/examples/trajectory"
+	"github.com/downflux/go-orca/orca"
+	"github.com/downflux/go-orca/orca/stall"
+	"github.com/downflux/go-orca/region"
+	"github.com/downflux/go-orca/region/arc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	exampleagent "github.com/downflux/go-orca/examples/agent"
+)
+
+// O is an options struct passed into Compute.
+type O struct {
+	// Agents is the agent layout of the recorded run. The i-th agent
+	// corresponds to trajectory records with Agent = i.
+	Agents []exampleagent.O
+
+	// R is a list of map regions of the recorded run.
+	R []region.R
+
+	// Tolerance is the maximum distance from its goal at which an agent is
+	// considered to have arrived.
+	Tolerance float64
+
+	// StallWindow is the duration of the sliding window of the stall
+	// detector; see orca/stall.
+	StallWindow float64
+
+	// StallThreshold is the mean velocity shortfall over the sliding window
+	// above which an agent is considered stalled.
+	StallThreshold float64
+}
+
+// M is the set of metrics aggregated over all agents.
+//
+// Separations are measured between agent boundaries (and wall boundaries, for
+// thick walls), and are negative for overlapping agents. Separations are not
+// set if the run does not contain any agent pairs or walls, respectively.
+type M struct {
+	// Collisions is the number of agent-agent collisions. A collision is
+	// counted once when two agents start overlapping.
+	Collisions int
+
+	// WallCollisions is the number of agent-wall collisions. A collision is
+	// counted once per region when an agent starts overlapping any wall of
+	// the region.
+	WallCollisions int
+
+	// Penetration is the maximum overlap of any collision.
+	Penetration float64
+
+	MinSeparation     *float64
+	MinWallSeparation *float64
+
+	// Arrived is the number of agents which reached their goal.
+	Arrived int
+
+	Agents []A
+}
+
+// A is the set of metrics of a single agent.
+type A struct {
+	Collisions     int
+	WallCollisions int
+	Penetration    float64
+
+	MinSeparation     *float64
+	MinWallSeparation *float64
+
+	// TimeToGoal is the time at which the agent first arrived within
+	// tolerance of its goal, relative to the start of the run. TimeToGoal
+	// is not set if the agent never arrived.
+	TimeToGoal *float64
+
+	// PathLength is the total distance travelled by the agent.
+	PathLength float64
+
+	// PathRatio is the ratio of the path length to the straight line
+	// distance between the initial agent position and its goal. PathRatio
+	// is not set if the agent starts at its goal.
+	PathRatio *float64
+
+	// MaxJerk and MeanJerk are the maximum and mean magnitudes of the rate
+	// of change of the agent acceleration, as estimated from the recorded
+	// velocities.
+	//
+	// N.B.: The velocity recorded at a tick is the velocity which moved the
+	// agent to the recorded position; the velocity recorded at the first
+	// tick is the initial velocity of the agent, which is never used to
+	// move the agent, and is therefore ignored.
+	MaxJerk  float64
+	MeanJerk float64
+
+	// StallTime is the total time the agent was considered stalled.
+	StallTime float64
+}
+
+// frame is the state of all agents at a single tick.
+type frame struct {
+	t  float64
+	ps []vector.V
+	vs []vector.V
+}
+
+// frames groups the input records by tick, and checks each tick contains
+// exactly one record per agent.
+func frames(rs []trajectory.R, n int) ([]frame, error) {
+	ticks := map[int]*frame{}
+	for _, r := range rs {
+		if r.Agent < 0 || r.Agent >= n {
+			return nil, status.Errorf(codes.InvalidArgument, "record for tick %v references unknown agent %v", r.Tick, r.Agent)
+		}
+		f, ok := ticks[r.Tick]
+		if !ok {
+			f = &frame{t: r.T, ps: make([]vector.V, n), vs: make([]vector.V, n)}
+			ticks[r.Tick] = f
+		}
+		if f.ps[r.Agent] != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate record for agent %v at tick %v", r.Agent, r.Tick)
+		}
+		f.ps[r.Agent] = r.P
+		f.vs[r.Agent] = r.V
+	}
+
+	ks := make([]int, 0, len(ticks))
+	for k := range ticks {
+		ks = append(ks, k)
+	}
+	sort.Ints(ks)
+
+	fs := make([]frame, 0, len(ks))
+	for _, k := range ks {
+		f := ticks[k]
+		for i, p := range f.ps {
+			if p == nil {
+				return nil, status.Errorf(codes.InvalidArgument, "missing record for agent %v at tick %v", i, k)
+			}
+		}
+		fs = append(fs, *f)
+	}
+	return fs, nil
+}
+
+// lower returns the smaller of the current minimum m and v.
+func lower(m *float64, v float64) *float64 {
+	if m == nil || v < *m {
+		return &v
+	}
+	return m
+}
+
+// Compute calculates the metrics of the input recorded run.
+func Compute(o O, rs []trajectory.R) (M, error) {
+	n := len(o.Agents)
+	fs, err := frames(rs, n)
+	if err != nil {
+		return M{}, err
+	}
+
+	as := make([]A, n)
+	m := M{Agents: as}
+	if len(fs) == 0 {
+		return m, nil
+	}
+
+	// Overlaps are tracked across ticks, so that each collision is only
+	// counted once.
+	type pair struct{ i, j int }
+	type wall struct{ i, r int }
+	overlaps := map[pair]bool{}
+	walls := map[wall]bool{}
+
+	for _, f := range fs {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				d := vector.Magnitude(vector.Sub(f.ps[i], f.ps[j])) - o.Agents[i].R - o.Agents[j].R
+				as[i].MinSeparation = lower(as[i].MinSeparation, d)
+				as[j].MinSeparation = lower(as[j].MinSeparation, d)
+				m.MinSeparation = lower(m.MinSeparation, d)
+
+				k := pair{i, j}
+				if d < 0 && !overlaps[k] {
+					m.Collisions++
+					as[i].Collisions++
+					as[j].Collisions++
+				}
+				overlaps[k] = d < 0
+				if d < 0 {
+					as[i].Penetration = math.Max(as[i].Penetration, -d)
+					as[j].Penetration = math.Max(as[j].Penetration, -d)
+					m.Penetration = math.Max(m.Penetration, -d)
+				}
+			}
+
+			for r, g := range o.R {
+				var w float64
+				if h, ok := g.(region.W); ok {
+					w = h.W() / 2
+				}
+				var cs []arc.A
+				if h, ok := g.(region.A); ok {
+					cs = h.A()
+				}
+				if len(g.R()) == 0 && len(cs) == 0 {
+					continue
+				}
+
+				// Collisions are counted per region, so that an
+				// agent sliding across the joint between two
+				// connected segments only collides once.
+				d := math.Inf(0)
+				for _, t := range g.R() {
+					d = math.Min(d, vector.Magnitude(vector.Sub(f.ps[i], t.L().L(t.T(f.ps[i]))))-o.Agents[i].R-w)
+				}
+				for _, c := range cs {
+					d = math.Min(d, c.Distance(f.ps[i])-o.Agents[i].R-w)
+				}
+				as[i].MinWallSeparation = lower(as[i].MinWallSeparation, d)
+				m.MinWallSeparation = lower(m.MinWallSeparation, d)
+
+				k := wall{i, r}
+				if d < 0 && !walls[k] {
+					m.WallCollisions++
+					as[i].WallCollisions++
+				}
+				walls[k] = d < 0
+				if d < 0 {
+					as[i].Penetration = math.Max(as[i].Penetration, -d)
+					m.Penetration = math.Max(m.Penetration, -d)
+				}
+			}
+		}
+	}
+
+	for i, c := range o.Agents {
+		a := &as[i]
+
+		for _, f := range fs {
+			if vector.Magnitude(vector.Sub(c.G, f.ps[i])) <= o.Tolerance {
+				t := f.t - fs[0].t
+				a.TimeToGoal = &t
+				m.Arrived++
+				break
+			}
+		}
+
+		for k := 1; k < len(fs); k++ {
+			a.PathLength += vector.Magnitude(vector.Sub(fs[k].ps[i], fs[k-1].ps[i]))
+		}
+		if d := vector.Magnitude(vector.Sub(c.G, fs[0].ps[i])); d > 0 {
+			r := a.PathLength / d
+			a.PathRatio = &r
+		}
+
+		var sum float64
+		for k := 2; k+1 < len(fs); k++ {
+			d1 := fs[k].t - fs[k-1].t
+			d2 := fs[k+1].t - fs[k].t
+			if d1 <= 0 || d2 <= 0 {
+				return M{}, status.Errorf(codes.InvalidArgument, "record times must be strictly increasing, but got %v, %v, %v at ticks %v through %v", fs[k-1].t, fs[k].t, fs[k+1].t, k-1, k+1)
+			}
+			a1 := vector.Scale(1/d1, vector.Sub(fs[k].vs[i], fs[k-1].vs[i]))
+			a2 := vector.Scale(1/d2, vector.Sub(fs[k+1].vs[i], fs[k].vs[i]))
+			j := vector.Magnitude(vector.Sub(a2, a1)) / ((d1 + d2) / 2)
+
+			a.MaxJerk = math.Max(a.MaxJerk, j)
+			sum += j
+		}
+		if len(fs) > 3 {
+			a.MeanJerk = sum / float64(len(fs)-3)
+		}
+	}
+
+	if err := stalls(o, fs, as); err != nil {
+		return M{}, err
+	}
+
+	return m, nil
+}
+
+// stalls replays the recorded velocities through a stall detector, and
+// accumulates the time each agent is considered stalled.
+func stalls(o O, fs []frame, as []A) error {
+	if len(fs) < 2 {
+		return nil
+	}
+
+	dt := fs[1].t - fs[0].t
+	if dt <= 0 {
+		return status.Errorf(codes.InvalidArgument, "record times must be strictly increasing, but got %v, %v at ticks 0 through 1", fs[0].t, fs[1].t)
+	}
+	w := int(math.Round(o.StallWindow / dt))
+	if w < 1 {
+		return status.Errorf(codes.InvalidArgument, "stall window %v must be at least a single tick of duration %v", o.StallWindow, dt)
+	}
+
+	d := stall.New(stall.O{W: w, S: o.StallThreshold})
+
+	agents := make([]*exampleagent.A, 0, len(o.Agents))
+	indices := make(map[*exampleagent.A]int, len(o.Agents))
+	for i, c := range o.Agents {
+		a := exampleagent.New(c)
+		agents = append(agents, a)
+		indices[a] = i
+	}
+
+	for k := 1; k < len(fs); k++ {
+		// The velocity recorded at tick k was chosen by the agent at
+		// the position recorded at tick k - 1.
+		ms := make([]orca.Mutation, 0, len(agents))
+		for i, a := range agents {
+			a.SetP(fs[k-1].ps[i])
+			ms = append(ms, orca.Mutation{A: a, V: fs[k].vs[i]})
+		}
+		d.Add(ms)
+
+		for _, a := range d.Stalled() {
+			as[indices[a.(*exampleagent.A)]].StallTime += fs[k].t - fs[k-1].t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/examples/agent"
+	"github.com/downflux/go-orca/examples/scenario"
+	"github.com/downflux/go-orca/examples/trajectory"
+	"github.com/downflux/go-orca/region"
+	"github.com/downflux/go-orca/region/arc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	examplesegment "github.com/downflux/go-orca/examples/segment"
+)
+
+func f(v float64) *float64 { return &v }
+
+// rs generates trajectory records with a tick duration of 1 from the input
+// per-agent positions and velocities, indexed by tick and then by agent.
+func rs(ps [][]vector.V, vs [][]vector.V) []trajectory.R {
+	var rs []trajectory.R
+	for k := range ps {
+		for i := range ps[k] {
+			rs = append(rs, trajectory.R{
+				Tick:  k,
+				T:     float64(k),
+				Agent: i,
+				P:     ps[k][i],
+				V:     vs[k][i],
+			})
+		}
+	}
+	return rs
+}
+
+// curved is a region consisting only of curved walls.
+type curved []arc.A
+
+func (curved) R() []segment.S { return nil }
+func (r curved) A() []arc.A   { return r }
+
+func TestCompute(t *testing.T) {
+	type config struct {
+		name string
+		o    O
+		rs   []trajectory.R
+		want M
+		succ bool
+	}
+
+	o := func(as []agent.O, r []region.R) O {
+		return O{
+			Agents:         as,
+			R:              r,
+			Tolerance:      0.1,
+			StallWindow:    1,
+			StallThreshold: 0.9,
+		}
+	}
+
+	testConfigs := []config{
+		{
+			name: "Straight",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(3, 0), S: 1, R: 1},
+			}, nil),
+			rs: rs(
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(1, 0)}, {*vector.New(2, 0)}, {*vector.New(3, 0)}},
+				[][]vector.V{{*vector.New(1, 0)}, {*vector.New(1, 0)}, {*vector.New(1, 0)}, {*vector.New(1, 0)}},
+			),
+			want: M{
+				Arrived: 1,
+				Agents: []A{
+					{TimeToGoal: f(3), PathLength: 3, PathRatio: f(1)},
+				},
+			},
+			succ: true,
+		},
+		{
+			name: "Collision",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(0, 0), S: 1, R: 1},
+				{P: *vector.New(3, 0), G: *vector.New(3, 0), S: 1, R: 1},
+			}, nil),
+			rs: rs(
+				[][]vector.V{
+					{*vector.New(0, 0), *vector.New(3, 0)},
+					{*vector.New(0, 0), *vector.New(1.5, 0)},
+					{*vector.New(0, 0), *vector.New(3, 0)},
+					{*vector.New(0, 0), *vector.New(1, 0)},
+				},
+				[][]vector.V{
+					{*vector.New(0, 0), *vector.New(0, 0)},
+					{*vector.New(0, 0), *vector.New(0, 0)},
+					{*vector.New(0, 0), *vector.New(0, 0)},
+					{*vector.New(0, 0), *vector.New(0, 0)},
+				},
+			),
+			want: M{
+				Collisions:    2,
+				Penetration:   1,
+				MinSeparation: f(-1),
+				Arrived:       2,
+				Agents: []A{
+					{Collisions: 2, Penetration: 1, MinSeparation: f(-1), TimeToGoal: f(0)},
+					// The agent is pushed away from its goal at tick 1,
+					// and does not move during tick 2.
+					{Collisions: 2, Penetration: 1, MinSeparation: f(-1), TimeToGoal: f(0), PathLength: 5, StallTime: 1},
+				},
+			},
+			succ: true,
+		},
+		{
+			name: "Wall",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(0, 0), S: 1, R: 1},
+			}, []region.R{
+				*examplesegment.New(examplesegment.O{P: *vector.New(-1, 0.5), D: *vector.New(1, 0), TMin: 0, TMax: 2}),
+			}),
+			rs: rs(
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}},
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}},
+			),
+			want: M{
+				WallCollisions:    1,
+				Penetration:       0.5,
+				MinWallSeparation: f(-0.5),
+				Arrived:           1,
+				Agents: []A{
+					{WallCollisions: 1, Penetration: 0.5, MinWallSeparation: f(-0.5), TimeToGoal: f(0)},
+				},
+			},
+			succ: true,
+		},
+		{
+			// The wall is the capsule of points within W / 2 = 1 of
+			// the segment.
+			name: "Wall/Thick",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(0, 0), S: 1, R: 1},
+			}, []region.R{
+				*examplesegment.New(examplesegment.O{P: *vector.New(-1, 1.5), D: *vector.New(1, 0), TMin: 0, TMax: 2, W: 2}),
+			}),
+			rs: rs(
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}},
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}},
+			),
+			want: M{
+				WallCollisions:    1,
+				Penetration:       0.5,
+				MinWallSeparation: f(-0.5),
+				Arrived:           1,
+				Agents: []A{
+					{WallCollisions: 1, Penetration: 0.5, MinWallSeparation: f(-0.5), TimeToGoal: f(0)},
+				},
+			},
+			succ: true,
+		},
+		{
+			// The agent slides across the joint of two connected
+			// walls while overlapping both, which is a single
+			// collision.
+			name: "Wall/Joint",
+			o: o([]agent.O{
+				{P: *vector.New(-1, 0), G: *vector.New(1, 0), S: 1, R: 1},
+			}, scenario.O{
+				Regions: []scenario.R{
+					{V: []vector.V{*vector.New(-2, 0.5), *vector.New(0, 0.5), *vector.New(2, 0.5)}},
+				},
+			}.R()),
+			rs: rs(
+				[][]vector.V{{*vector.New(-1, 0)}, {*vector.New(0, 0)}, {*vector.New(1, 0)}},
+				[][]vector.V{{*vector.New(1, 0)}, {*vector.New(1, 0)}, {*vector.New(1, 0)}},
+			),
+			want: M{
+				WallCollisions:    1,
+				Penetration:       0.5,
+				MinWallSeparation: f(-0.5),
+				Arrived:           1,
+				Agents: []A{
+					{WallCollisions: 1, Penetration: 0.5, MinWallSeparation: f(-0.5), TimeToGoal: f(2), PathLength: 2, PathRatio: f(1)},
+				},
+			},
+			succ: true,
+		},
+		{
+			// The wall is the upper half of the circle of radius 2
+			// centered on (0, -2.5), i.e. the closest point on the
+			// wall to the agent is (0, -0.5).
+			name: "Wall/Arc",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(0, 0), S: 1, R: 1},
+			}, []region.R{
+				curved{*arc.New(*vector.New(0, -2.5), 2, 0, math.Pi)},
+			}),
+			rs: rs(
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}},
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}},
+			),
+			want: M{
+				WallCollisions:    1,
+				Penetration:       0.5,
+				MinWallSeparation: f(-0.5),
+				Arrived:           1,
+				Agents: []A{
+					{WallCollisions: 1, Penetration: 0.5, MinWallSeparation: f(-0.5), TimeToGoal: f(0)},
+				},
+			},
+			succ: true,
+		},
+		{
+			name: "Stall",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(10, 0), S: 1, R: 1},
+			}, nil),
+			rs: rs(
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}, {*vector.New(0, 0)}, {*vector.New(0, 0)}},
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}, {*vector.New(0, 0)}, {*vector.New(0, 0)}},
+			),
+			want: M{
+				Agents: []A{
+					{PathRatio: f(0), StallTime: 3},
+				},
+			},
+			succ: true,
+		},
+		{
+			name: "Jerk",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(0, 0), S: 1, R: 1},
+			}, nil),
+			rs: rs(
+				[][]vector.V{{*vector.New(0, 0)}, {*vector.New(0, 0)}, {*vector.New(1, 0)}, {*vector.New(1, 0)}},
+				// The initial velocity does not contribute to the
+				// jerk.
+				[][]vector.V{{*vector.New(100, 0)}, {*vector.New(0, 0)}, {*vector.New(1, 0)}, {*vector.New(0, 0)}},
+			),
+			want: M{
+				Arrived: 1,
+				Agents: []A{
+					{TimeToGoal: f(0), PathLength: 1, MaxJerk: 2, MeanJerk: 2, StallTime: 1},
+				},
+			},
+			succ: true,
+		},
+		{
+			name: "Missing",
+			o: o([]agent.O{
+				{P: *vector.New(0, 0), G: *vector.New(0, 0), S: 1, R: 1},
+				{P: *vector.New(3, 0), G: *vector.New(3, 0), S: 1, R: 1},
+			}, nil),
+			rs: []trajectory.R{
+				{Tick: 0, Agent: 0, P: *vector.New(0, 0), V: *vector.New(0, 0)},
+			},
+			succ: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Compute(c.o, c.rs)
+			if succ := err == nil; succ != c.succ {
+				t.Fatalf("Compute() returned error %v, want success = %v", err, c.succ)
+			}
+			if !c.succ {
+				return
+			}
+			if diff := cmp.Diff(c.want, got, cmpopts.EquateApprox(0, 1e-10)); diff != "" {
+				t.Errorf("Compute() mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
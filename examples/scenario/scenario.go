@@ -0,0 +1,277 @@
+// Package scenario defines a versioned scenario format for the demo apps.
+//
+// A scenario describes the agents and walls of a simulation, the parameters
+// used to run the simulation, and free-form metadata. Scenarios are encoded as
+// JSON, e.g.
+//
+//	{
+//	  "Version": 1,
+//	  "Metadata": {"Name": "corridor"},
+//	  "Sim": {"Tau": 0.9, "Framerate": 60, "ORCAInterval": 1, "Ticks": 120},
+//	  "Agents": [{"P": [0, 0], "G": [100, 0], "S": 55, "R": 10}],
+//	  "Walls": [{"P": [0, 20], "D": [1, 0], "TMin": 0, "TMax": 100, "W": 0}],
+//	  "Regions": [{"V": [[0, -20], [100, -20], [100, -40]], "Closed": false}]
+//	}
+//
+// Load also accepts the legacy unversioned config.O layout, which is migrated
+// to the current version.
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/downflux/go-geometry/2d/line"
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/examples/agent"
+	"github.com/downflux/go-orca/examples/config"
+	"github.com/downflux/go-orca/region"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	examplesegment "github.com/downflux/go-orca/examples/segment"
+)
+
+// Version is the current scenario format version.
+const Version = 1
+
+var (
+	_ region.W = &chain{}
+)
+
+// O is a scenario.
+type O struct {
+	Version int
+
+	Metadata M
+	Sim      S
+
+	Agents []agent.O
+
+	// Walls is a list of independent wall segments.
+	Walls []examplesegment.O
+
+	// Regions is a list of connected walls, e.g. the outline of a
+	// building.
+	Regions []R
+}
+
+// M is free-form scenario metadata, which is ignored by the simulation.
+type M struct {
+	Name        string
+	Description string
+	Tags        []string
+}
+
+// S is the set of simulation parameters.
+type S struct {
+	// Tau is the lookahead time passed into ORCA. Agents beyond the
+	// distance they may travel within Tau are not considered for collision
+	// avoidance.
+	//
+	// Tau should be at least the time in between ORCA calls, i.e.
+	// ORCAInterval / Framerate, so that agents cannot collide in between
+	// calls; if Tau is too small however, agents only avoid each other at
+	// the very last moment, which results in unnatural simulations. The
+	// default of 0.9s was determined experimentally to look good.
+	Tau float64
+
+	// Framerate is the number of simulation ticks per second.
+	Framerate float64
+
+	// ORCAInterval dictates how many ticks to skip before calling ORCA.
+	ORCAInterval int
+
+	// Ticks is the number of simulation ticks to run.
+	Ticks int
+}
+
+// R is a region of connected walls, formed by the line segments between
+// consecutive vertices.
+type R struct {
+	V []vector.V
+
+	// Closed connects the last vertex back to the first vertex.
+	Closed bool
+
+	// W is the thickness of the walls, i.e. each wall is the capsule of
+	// points within W / 2 of its segment.
+	W float64
+}
+
+// DT returns the duration of a single simulation tick.
+func (o O) DT() float64 { return 1 / o.Sim.Framerate }
+
+// R returns the walls and regions of the scenario as map regions.
+func (o O) R() []region.R {
+	rs := make([]region.R, 0, len(o.Walls)+len(o.Regions))
+	for _, w := range o.Walls {
+		rs = append(rs, *examplesegment.New(w))
+	}
+	for _, r := range o.Regions {
+		rs = append(rs, newChain(r))
+	}
+	return rs
+}
+
+// Migrate converts a legacy demo config into a scenario. The simulation
+// parameters are set to the defaults of the demo app.
+func Migrate(c config.O) O {
+	return O{
+		Version: Version,
+		Sim: S{
+			Tau:          0.9,
+			Framerate:    60,
+			ORCAInterval: 1,
+			Ticks:        120,
+		},
+		Agents: c.Agents,
+		Walls:  c.Segments,
+	}
+}
+
+// Load decodes and validates a JSON-encoded scenario. Legacy demo configs,
+// i.e. inputs without a version, are migrated to the current version.
+//
+// If the scenario is invalid, the returned error is an InvalidArgument status
+// error, whose BadRequest details list all invalid fields; see Violations.
+func Load(data []byte) (O, error) {
+	var v struct {
+		Version *int
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return O{}, status.Errorf(codes.InvalidArgument, "cannot decode scenario: %v", err)
+	}
+
+	var o O
+	switch {
+	case v.Version == nil:
+		var c config.O
+		if err := json.Unmarshal(data, &c); err != nil {
+			return O{}, status.Errorf(codes.InvalidArgument, "cannot decode legacy config: %v", err)
+		}
+		o = Migrate(c)
+	case *v.Version == Version:
+		if err := json.Unmarshal(data, &o); err != nil {
+			return O{}, status.Errorf(codes.InvalidArgument, "cannot decode scenario: %v", err)
+		}
+	default:
+		return O{}, status.Errorf(codes.Unimplemented, "unsupported scenario version %v, current version is %v", *v.Version, Version)
+	}
+
+	if err := Validate(o); err != nil {
+		return O{}, err
+	}
+	return o, nil
+}
+
+// Validate checks the input scenario for invalid fields, e.g. agents with a
+// non-positive radius, or degenerate wall segments.
+func Validate(o O) error {
+	var vs []*errdetails.BadRequest_FieldViolation
+	check := func(ok bool, field string, format string, args ...any) {
+		if !ok {
+			vs = append(vs, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: fmt.Sprintf(format, args...),
+			})
+		}
+	}
+
+	check(o.Version == Version, "Version", "must be %v, but got %v", Version, o.Version)
+
+	check(o.Sim.Tau > 0, "Sim.Tau", "must be positive, but got %v", o.Sim.Tau)
+	check(o.Sim.Framerate > 0, "Sim.Framerate", "must be positive, but got %v", o.Sim.Framerate)
+	check(o.Sim.ORCAInterval >= 1, "Sim.ORCAInterval", "must be at least 1, but got %v", o.Sim.ORCAInterval)
+	check(o.Sim.Ticks >= 0, "Sim.Ticks", "must be non-negative, but got %v", o.Sim.Ticks)
+
+	for i, a := range o.Agents {
+		p := fmt.Sprintf("Agents[%v]", i)
+		check(len(a.P) == 2, p+".P", "must be a 2D vector, but got %v", a.P)
+		check(len(a.G) == 2, p+".G", "must be a 2D vector, but got %v", a.G)
+		check(a.R > 0, p+".R", "must be positive, but got %v", a.R)
+		check(a.S > 0, p+".S", "must be positive, but got %v", a.S)
+	}
+
+	for i, w := range o.Walls {
+		p := fmt.Sprintf("Walls[%v]", i)
+		check(len(w.P) == 2, p+".P", "must be a 2D vector, but got %v", w.P)
+		check(len(w.D) == 2 && !vector.Within(w.D, *vector.New(0, 0)), p+".D", "must be a non-zero 2D vector, but got %v", w.D)
+		check(w.TMax > w.TMin, p+".TMax", "must be greater than TMin = %v, but got %v", w.TMin, w.TMax)
+		check(w.W >= 0, p+".W", "must be non-negative, but got %v", w.W)
+	}
+
+	for i, r := range o.Regions {
+		p := fmt.Sprintf("Regions[%v]", i)
+		check(len(r.V) >= 2, p+".V", "must have at least 2 vertices, but got %v", len(r.V))
+		for j, v := range r.V {
+			q := fmt.Sprintf("%v.V[%v]", p, j)
+			check(len(v) == 2, q, "must be a 2D vector, but got %v", v)
+			if j > 0 && len(v) == 2 && len(r.V[j-1]) == 2 {
+				check(!vector.Within(v, r.V[j-1]), q, "must be distinct from the previous vertex %v", r.V[j-1])
+			}
+		}
+		if r.Closed {
+			check(len(r.V) >= 3, p+".Closed", "must have at least 3 vertices, but got %v", len(r.V))
+			if n := len(r.V); n >= 2 && len(r.V[0]) == 2 && len(r.V[n-1]) == 2 {
+				check(!vector.Within(r.V[n-1], r.V[0]), fmt.Sprintf("%v.V[%v]", p, n-1), "must be distinct from the first vertex %v of a closed region", r.V[0])
+			}
+		}
+		check(r.W >= 0, p+".W", "must be non-negative, but got %v", r.W)
+	}
+
+	if len(vs) == 0 {
+		return nil
+	}
+
+	fs := make([]string, 0, len(vs))
+	for _, v := range vs {
+		fs = append(fs, fmt.Sprintf("%v: %v", v.GetField(), v.GetDescription()))
+	}
+	s, err := status.New(
+		codes.InvalidArgument,
+		fmt.Sprintf("invalid scenario: %v", strings.Join(fs, "; ")),
+	).WithDetails(&errdetails.BadRequest{FieldViolations: vs})
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot attach error details: %v", err)
+	}
+	return s.Err()
+}
+
+// Violations returns the invalid fields reported by the input Load or Validate
+// error.
+func Violations(err error) []*errdetails.BadRequest_FieldViolation {
+	var vs []*errdetails.BadRequest_FieldViolation
+	for _, d := range status.Convert(err).Details() {
+		if b, ok := d.(*errdetails.BadRequest); ok {
+			vs = append(vs, b.GetFieldViolations()...)
+		}
+	}
+	return vs
+}
+
+// chain is a region of connected wall segments.
+type chain struct {
+	ss []segment.S
+	w  float64
+}
+
+func newChain(r R) *chain {
+	n := len(r.V) - 1
+	if r.Closed {
+		n = len(r.V)
+	}
+
+	ss := make([]segment.S, 0, n)
+	for i := 0; i < n; i++ {
+		p, q := r.V[i], r.V[(i+1)%len(r.V)]
+		ss = append(ss, *segment.New(*line.New(p, vector.Sub(q, p)), 0, 1))
+	}
+	return &chain{ss: ss, w: r.W}
+}
+
+func (c *chain) R() []segment.S { return c.ss }
+func (c *chain) W() float64     { return c.w }
@@ -0,0 +1,176 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoad(t *testing.T) {
+	type config struct {
+		name string
+		data string
+
+		code codes.Code
+
+		// fields is the list of invalid fields reported by Load.
+		fields []string
+	}
+
+	testConfigs := []config{
+		{
+			name: "Valid",
+			data: `{
+				"Version": 1,
+				"Metadata": {"Name": "corridor"},
+				"Sim": {"Tau": 0.9, "Framerate": 60, "ORCAInterval": 1, "Ticks": 120},
+				"Agents": [{"P": [0, 0], "G": [100, 0], "S": 55, "R": 10}],
+				"Walls": [{"P": [0, 20], "D": [1, 0], "TMin": 0, "TMax": 100}],
+				"Regions": [{"V": [[0, -20], [100, -20], [100, -40]]}]
+			}`,
+			code: codes.OK,
+		},
+		{
+			name: "Legacy",
+			data: `{
+				"Agents": [{"P": [0, 0], "G": [100, 0], "S": 55, "R": 10}],
+				"Segments": [{"P": [0, 20], "D": [1, 0], "TMin": 0, "TMax": 100}]
+			}`,
+			code: codes.OK,
+		},
+		{
+			name: "Legacy/Invalid",
+			data: `{
+				"Agents": [{"P": [0, 0], "G": [100, 0], "S": 55, "R": -10}]
+			}`,
+			code:   codes.InvalidArgument,
+			fields: []string{"Agents[0].R"},
+		},
+		{
+			name: "Malformed",
+			data: `{"Version": 1,`,
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Version",
+			data: `{"Version": 2}`,
+			code: codes.Unimplemented,
+		},
+		{
+			name: "Invalid",
+			data: `{
+				"Version": 1,
+				"Sim": {"Tau": 0, "Framerate": 60, "ORCAInterval": 0, "Ticks": 120},
+				"Agents": [
+					{"P": [0, 0], "G": [100, 0], "S": 55, "R": 10},
+					{"P": [0], "G": [100, 0], "S": 0, "R": 10}
+				],
+				"Walls": [{"P": [0, 20], "D": [0, 0], "TMin": 1, "TMax": 1}],
+				"Regions": [{"V": [[0, -20], [0, -20]], "W": -1}]
+			}`,
+			code: codes.InvalidArgument,
+			fields: []string{
+				"Sim.Tau",
+				"Sim.ORCAInterval",
+				"Agents[1].P",
+				"Agents[1].S",
+				"Walls[0].D",
+				"Walls[0].TMax",
+				"Regions[0].V[1]",
+				"Regions[0].W",
+			},
+		},
+		{
+			name: "Closed/Repeated",
+			data: `{
+				"Version": 1,
+				"Sim": {"Tau": 0.9, "Framerate": 60, "ORCAInterval": 1, "Ticks": 120},
+				"Regions": [{"V": [[0, 0], [1, 0], [1, 1], [0, 0]], "Closed": true}]
+			}`,
+			code:   codes.InvalidArgument,
+			fields: []string{"Regions[0].V[3]"},
+		},
+		{
+			name: "Closed/Degenerate",
+			data: `{
+				"Version": 1,
+				"Sim": {"Tau": 0.9, "Framerate": 60, "ORCAInterval": 1, "Ticks": 120},
+				"Regions": [{"V": [[0, 0], [1, 0]], "Closed": true}]
+			}`,
+			code:   codes.InvalidArgument,
+			fields: []string{"Regions[0].Closed"},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Load([]byte(c.data))
+			if got := status.Code(err); got != c.code {
+				t.Fatalf("Load() returned error %v, want code = %v", err, c.code)
+			}
+
+			var got []string
+			for _, v := range Violations(err) {
+				got = append(got, v.GetField())
+			}
+			if diff := cmp.Diff(c.fields, got); diff != "" {
+				t.Errorf("Violations() mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestR(t *testing.T) {
+	type config struct {
+		name string
+		r    R
+		want [][2]vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name: "Open",
+			r: R{
+				V: []vector.V{*vector.New(0, 0), *vector.New(1, 0), *vector.New(1, 1)},
+			},
+			want: [][2]vector.V{
+				{*vector.New(0, 0), *vector.New(1, 0)},
+				{*vector.New(1, 0), *vector.New(1, 1)},
+			},
+		},
+		{
+			name: "Closed",
+			r: R{
+				V:      []vector.V{*vector.New(0, 0), *vector.New(1, 0), *vector.New(1, 1)},
+				Closed: true,
+			},
+			want: [][2]vector.V{
+				{*vector.New(0, 0), *vector.New(1, 0)},
+				{*vector.New(1, 0), *vector.New(1, 1)},
+				{*vector.New(1, 1), *vector.New(0, 0)},
+			},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			rs := O{Regions: []R{c.r}}.R()
+			if len(rs) != 1 {
+				t.Fatalf("len(R()) = %v, want = 1", len(rs))
+			}
+			ss := rs[0].R()
+			if len(ss) != len(c.want) {
+				t.Fatalf("len(R()[0].R()) = %v, want = %v", len(ss), len(c.want))
+			}
+			for i, s := range ss {
+				got := [2]vector.V{s.L().L(s.TMin()), s.L().L(s.TMax())}
+				if !vector.Within(got[0], c.want[i][0]) || !vector.Within(got[1], c.want[i][1]) {
+					t.Errorf("R()[0].R()[%v] = %v, want = %v", i, got, c.want[i])
+				}
+			}
+		})
+	}
+}
@@ -7,13 +7,17 @@ import (
 	"github.com/downflux/go-orca/region"
 )
 
-var _ region.R = S{}
+var _ region.W = S{}
 
 type O struct {
 	P    vector.V
 	D    vector.V
 	TMin float64
 	TMax float64
+
+	// W is the thickness of the wall, i.e. the wall is the capsule of
+	// points within W / 2 of the segment.
+	W float64
 }
 
 func New(o O) *S {
@@ -23,11 +27,14 @@ func New(o O) *S {
 			o.TMin,
 			o.TMax,
 		),
+		w: o.W,
 	}
 }
 
 type S struct {
 	s segment.S
+	w float64
 }
 
 func (r S) R() []segment.S { return []segment.S{r.s} }
+func (r S) W() float64     { return r.w }
@@ -0,0 +1,175 @@
+// Package simulation steps through an agent layout with ORCA, as in the demo
+// app, without rendering the simulation.
+package simulation
+
+import (
+	"runtime"
+
+	"github.com/downflux/go-geometry/nd/vector"
+	"github.com/downflux/go-kd/kd"
+	"github.com/downflux/go-kd/point"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/examples/trajectory"
+	"github.com/downflux/go-orca/orca"
+	"github.com/downflux/go-orca/orca/mode"
+	"github.com/downflux/go-orca/region"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v2d "github.com/downflux/go-geometry/2d/vector"
+	exampleagent "github.com/downflux/go-orca/examples/agent"
+)
+
+var _ point.P = &P{}
+
+type P exampleagent.A
+
+func (p *P) A() agent.A  { return (*exampleagent.A)(p) }
+func (p *P) P() vector.V { return vector.V((*exampleagent.A)(p).P()) }
+
+// O is an options struct passed into New.
+type O struct {
+	Agents []exampleagent.O
+
+	// R is a list of map regions.
+	R []region.R
+
+	// DT is the duration of a single simulation tick.
+	DT float64
+
+	// ORCAInterval dictates how many ticks to skip before calling ORCA.
+	// Agents keep their last velocity in between ORCA calls.
+	ORCAInterval int
+
+	// Tau is the lookahead time passed into ORCA.
+	Tau float64
+
+	// Mode determines how the velocity obstacles between agents are
+	// constructed.
+	Mode mode.M
+
+	// Warm warm starts the ORCA solver of each agent from its velocity in
+	// the previous tick; see orca.O.
+	Warm bool
+}
+
+// S is a headless simulation of an agent layout.
+type S struct {
+	o O
+
+	ps []*P
+	rs []region.R
+	tr *kd.KD[*P]
+
+	// ms is the output of the last ORCA call.
+	ms []orca.Mutation
+
+	tick int
+}
+
+func New(o O) (*S, error) {
+	if o.DT <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "dt must be positive, but got %v", o.DT)
+	}
+	if o.ORCAInterval < 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "ORCA interval must be at least 1, but got %v", o.ORCAInterval)
+	}
+	if o.Tau <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "tau must be positive, but got %v", o.Tau)
+	}
+
+	ps := make([]*P, 0, len(o.Agents))
+	for _, c := range o.Agents {
+		p := P(*exampleagent.New(c))
+		ps = append(ps, &p)
+	}
+
+	return &S{
+		o:  o,
+		ps: ps,
+		rs: o.R,
+		tr: kd.New(kd.O[*P]{
+			Data: ps,
+			K:    2,
+			N:    16,
+		}),
+	}, nil
+}
+
+// Agents returns the simulated agents, in the order of the input layout.
+func (s *S) Agents() []*exampleagent.A {
+	as := make([]*exampleagent.A, 0, len(s.ps))
+	for _, p := range s.ps {
+		as = append(as, p.A().(*exampleagent.A))
+	}
+	return as
+}
+
+// Segments returns the walls of the simulation.
+func (s *S) Segments() []region.R { return s.rs }
+
+// Mutations returns the agent velocities calculated by the last ORCA call, e.g.
+// for detecting stalled agents.
+func (s *S) Mutations() []orca.Mutation { return s.ms }
+
+// Tick returns the number of simulation ticks stepped through so far.
+func (s *S) Tick() int { return s.tick }
+
+// T returns the current simulation time.
+func (s *S) T() float64 { return float64(s.tick) * s.o.DT }
+
+// Step advances the simulation by a single tick. ORCA is called at the start of
+// the tick if the tick is a multiple of the ORCA interval.
+func (s *S) Step() error {
+	if s.tick%s.o.ORCAInterval == 0 {
+		res, err := orca.Step(orca.O[*P]{
+			T:        s.tr,
+			R:        s.rs,
+			Tau:      s.o.Tau,
+			F:        func(a agent.A) bool { return true },
+			Mode:     s.o.Mode,
+			Warm:     s.o.Warm,
+			PoolSize: 4 * runtime.GOMAXPROCS(0),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "error while stepping through ORCA: %v", err)
+		}
+		for _, m := range res {
+			m.A.(*exampleagent.A).SetV(m.V)
+		}
+		s.ms = res
+	}
+
+	for _, a := range s.Agents() {
+		a.SetP(v2d.Add(a.P(), v2d.Scale(s.o.DT, a.V())))
+	}
+
+	// Update the K-D tree, as positions have changed in the interim.
+	s.tr.Balance()
+
+	s.tick++
+	return nil
+}
+
+// Run advances the simulation by n ticks, and returns the state of all agents
+// at every tick, including the state before the first step.
+func (s *S) Run(n int) ([]trajectory.R, error) {
+	rs := make([]trajectory.R, 0, (n+1)*len(s.ps))
+	for i := 0; ; i++ {
+		for j, a := range s.Agents() {
+			rs = append(rs, trajectory.R{
+				Tick:  s.Tick(),
+				T:     s.T(),
+				Agent: j,
+				P:     a.P(),
+				V:     a.V(),
+			})
+		}
+		if i == n {
+			return rs, nil
+		}
+		if err := s.Step(); err != nil {
+			return nil, err
+		}
+	}
+}
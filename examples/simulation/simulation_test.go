@@ -0,0 +1,213 @@
+package simulation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/examples/agent"
+	examplesconfig "github.com/downflux/go-orca/examples/config"
+	"github.com/downflux/go-orca/examples/generator/generator"
+	"github.com/downflux/go-orca/examples/scenario"
+)
+
+func TestNew(t *testing.T) {
+	type config struct {
+		name string
+		o    O
+		succ bool
+	}
+
+	testConfigs := []config{
+		{name: "Valid", o: O{DT: 0.1, ORCAInterval: 1, Tau: 1}, succ: true},
+		{name: "DT", o: O{DT: 0, ORCAInterval: 1, Tau: 1}, succ: false},
+		{name: "ORCAInterval", o: O{DT: 0.1, ORCAInterval: 0, Tau: 1}, succ: false},
+		{name: "Tau", o: O{DT: 0.1, ORCAInterval: 1, Tau: 0}, succ: false},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := New(c.o); (err == nil) != c.succ {
+				t.Errorf("New() returned error %v, want success = %v", err, c.succ)
+			}
+		})
+	}
+}
+
+func TestStep(t *testing.T) {
+	// A single unobstructed agent travels towards its goal at its maximum
+	// speed. With an ORCA interval of 2, the velocity is only updated every
+	// other tick.
+	s, err := New(O{
+		Agents: []agent.O{
+			{P: *vector.New(0, 0), G: *vector.New(100, 0), S: 10, R: 1},
+		},
+		DT:           0.1,
+		ORCAInterval: 2,
+		Tau:          1,
+	})
+	if err != nil {
+		t.Fatalf("New() returned error %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := s.Step(); err != nil {
+			t.Fatalf("Step() returned error %v", err)
+		}
+	}
+	if got, want := len(s.Mutations()), 1; got != want {
+		t.Errorf("len(Mutations()) = %v, want = %v", got, want)
+	}
+
+	if got, want := s.Tick(), 4; got != want {
+		t.Errorf("Tick() = %v, want = %v", got, want)
+	}
+	if got, want := s.T(), 0.4; got != want {
+		t.Errorf("T() = %v, want = %v", got, want)
+	}
+	if got, want := s.Agents()[0].P(), *vector.New(4, 0); !vector.Within(got, want) {
+		t.Errorf("P() = %v, want = %v", got, want)
+	}
+}
+
+// TestRegions checks that the simulation steps through multi-segment regions
+// without error.
+func TestRegions(t *testing.T) {
+	sc := scenario.O{
+		Agents: []agent.O{
+			{P: *vector.New(0, -10), G: *vector.New(100, -10), S: 10, R: 1},
+			{P: *vector.New(50, 30), G: *vector.New(50, 100), S: 10, R: 1},
+		},
+		Regions: []scenario.R{
+			{V: []vector.V{*vector.New(0, -20), *vector.New(100, -20), *vector.New(100, -40)}},
+			{
+				V:      []vector.V{*vector.New(40, 40), *vector.New(60, 40), *vector.New(60, 60), *vector.New(40, 60)},
+				Closed: true,
+				W:      2,
+			},
+		},
+	}
+
+	s, err := New(O{
+		Agents:       sc.Agents,
+		R:            sc.R(),
+		DT:           0.1,
+		ORCAInterval: 1,
+		Tau:          1,
+	})
+	if err != nil {
+		t.Fatalf("New() returned error %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		if err := s.Step(); err != nil {
+			t.Fatalf("Step() returned error %v", err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	s, err := New(O{
+		Agents: []agent.O{
+			{P: *vector.New(0, 0), G: *vector.New(100, 0), S: 10, R: 1},
+			{P: *vector.New(0, 50), G: *vector.New(100, 50), S: 10, R: 1},
+		},
+		DT:           0.1,
+		ORCAInterval: 1,
+		Tau:          1,
+	})
+	if err != nil {
+		t.Fatalf("New() returned error %v", err)
+	}
+
+	rs, err := s.Run(2)
+	if err != nil {
+		t.Fatalf("Run() returned error %v", err)
+	}
+
+	// Run records the initial state, and the state after each step.
+	if got, want := len(rs), 6; got != want {
+		t.Fatalf("len(Run()) = %v, want = %v", got, want)
+	}
+	for i, r := range rs {
+		if got, want := r.Tick, i/2; got != want {
+			t.Errorf("Tick = %v, want = %v", got, want)
+		}
+		if got, want := r.Agent, i%2; got != want {
+			t.Errorf("Agent = %v, want = %v", got, want)
+		}
+	}
+	if got, want := rs[len(rs)-1].P, *vector.New(2, 50); !vector.Within(got, want) {
+		t.Errorf("P = %v, want = %v", got, want)
+	}
+}
+
+// BenchmarkWarm compares the cost of a single simulation tick with and without
+// warm starting the ORCA solver from the previous tick, over the grid and
+// random scenarios used in the demo app. Each scenario is first simulated for a
+// short while, so that the agent velocities reflect the solution of the
+// previous tick.
+//
+// N.B.: The warm start does not measurably speed up a tick in any of these
+// scenarios. The LP solve is only ~3% of a tick of Grid/N=400 per the CPU
+// profile, which is instead dominated by the neighbor queries, and the
+// run-to-run variance of a tick is ~20%. Over three runs of 20 ticks each, we
+// measured
+//
+//	Grid/N=100     4.3-4.4 ms/op cold, 3.0-4.9 ms/op warm
+//	Grid/N=400     18.7-20.3 ms/op cold, 16.8-25.3 ms/op warm
+//	Random/N=250   6.1-8.8 ms/op cold, 7.8-8.6 ms/op warm
+//	Random/N=1000  66-74 ms/op cold, 77-81 ms/op warm
+//
+// See BenchmarkSolve in internal/solver for the speed-up of the LP solve
+// itself.
+func BenchmarkWarm(b *testing.B) {
+	const (
+		// dt and tau are the simulation parameters of the demo app.
+		dt  = 1.0 / 60
+		tau = 0.9
+
+		// ticks is the number of ticks simulated before the benchmark.
+		ticks = 60
+	)
+
+	type config struct {
+		name string
+		c    examplesconfig.O
+	}
+
+	testConfigs := []config{
+		{name: "Grid/N=100", c: generator.G(10, 10, 55, 10)},
+		{name: "Grid/N=400", c: generator.G(20, 20, 55, 10)},
+		{name: "Random/N=250", c: generator.R(1000, 1000, 55, 10, 250)},
+		{name: "Random/N=1000", c: generator.R(1000, 1000, 55, 10, 1000)},
+	}
+
+	for _, c := range testConfigs {
+		for _, warm := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%v/Warm=%v", c.name, warm), func(b *testing.B) {
+				s, err := New(O{
+					Agents:       c.c.Agents,
+					DT:           dt,
+					ORCAInterval: 1,
+					Tau:          tau,
+					Warm:         warm,
+				})
+				if err != nil {
+					b.Fatalf("New() returned error %v", err)
+				}
+				for i := 0; i < ticks; i++ {
+					if err := s.Step(); err != nil {
+						b.Fatalf("Step() returned error %v", err)
+					}
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if err := s.Step(); err != nil {
+						b.Fatalf("Step() returned error %v", err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package trajectory defines the per-tick agent state records emitted by the
+// headless simulation app, and encodes and decodes them as either JSON lines or
+// CSV.
+package trajectory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type F string
+
+const (
+	// JSON encodes each record as a single JSON object per line.
+	JSON F = "jsonl"
+
+	// CSV encodes each record as a single CSV row, with columns
+	//
+	//	tick, t, agent, x, y, vx, vy
+	//
+	// The first row is the header.
+	CSV F = "csv"
+)
+
+// R is the state of a single agent at a single simulation tick.
+type R struct {
+	Tick  int
+	T     float64
+	Agent int
+
+	P vector.V
+	V vector.V
+}
+
+// W encodes trajectory records into an underlying writer.
+type W struct {
+	f F
+
+	j *json.Encoder
+	c *csv.Writer
+}
+
+func New(w io.Writer, f F) (*W, error) {
+	switch f {
+	case JSON:
+		return &W{f: f, j: json.NewEncoder(w)}, nil
+	case CSV:
+		c := csv.NewWriter(w)
+		if err := c.Write([]string{"tick", "t", "agent", "x", "y", "vx", "vy"}); err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot write CSV header: %v", err)
+		}
+		return &W{f: f, c: c}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid trajectory format %v, must be one of (%v | %v)", f, JSON, CSV)
+	}
+}
+
+// Write encodes a single record. Writes may be buffered until Flush is called.
+func (w *W) Write(r R) error {
+	if w.f == JSON {
+		return w.j.Encode(r)
+	}
+
+	ff := func(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) }
+	return w.c.Write([]string{
+		strconv.Itoa(r.Tick),
+		ff(r.T),
+		strconv.Itoa(r.Agent),
+		ff(r.P.X()),
+		ff(r.P.Y()),
+		ff(r.V.X()),
+		ff(r.V.Y()),
+	})
+}
+
+// Flush writes any buffered records to the underlying writer.
+func (w *W) Flush() error {
+	if w.f == JSON {
+		return nil
+	}
+	w.c.Flush()
+	return w.c.Error()
+}
+
+// Read decodes all records from the input reader.
+func Read(r io.Reader, f F) ([]R, error) {
+	var rs []R
+	switch f {
+	case JSON:
+		d := json.NewDecoder(r)
+		for {
+			var t R
+			if err := d.Decode(&t); errors.Is(err, io.EOF) {
+				return rs, nil
+			} else if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "cannot decode record %v: %v", len(rs), err)
+			}
+			rs = append(rs, t)
+		}
+	case CSV:
+		c := csv.NewReader(r)
+		c.FieldsPerRecord = 7
+		if _, err := c.Read(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot read CSV header: %v", err)
+		}
+		for {
+			row, err := c.Read()
+			if errors.Is(err, io.EOF) {
+				return rs, nil
+			} else if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "cannot read record %v: %v", len(rs), err)
+			}
+			t, err := parse(row)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "cannot parse record %v: %v", len(rs), err)
+			}
+			rs = append(rs, t)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid trajectory format %v, must be one of (%v | %v)", f, JSON, CSV)
+	}
+}
+
+// parse decodes a single CSV row.
+func parse(row []string) (R, error) {
+	var is [2]int
+	for i, j := range []int{0, 2} {
+		n, err := strconv.Atoi(row[j])
+		if err != nil {
+			return R{}, err
+		}
+		is[i] = n
+	}
+
+	var fs [5]float64
+	for i, j := range []int{1, 3, 4, 5, 6} {
+		f, err := strconv.ParseFloat(row[j], 64)
+		if err != nil {
+			return R{}, err
+		}
+		fs[i] = f
+	}
+
+	return R{
+		Tick:  is[0],
+		T:     fs[0],
+		Agent: is[1],
+		P:     *vector.New(fs[1], fs[2]),
+		V:     *vector.New(fs[3], fs[4]),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package trajectory
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteRead(t *testing.T) {
+	type config struct {
+		name string
+		f    F
+		succ bool
+		want string
+	}
+
+	rs := []R{
+		{Tick: 0, T: 0, Agent: 0, P: *vector.New(1, 2), V: *vector.New(0, 0)},
+		{Tick: 1, T: 0.5, Agent: 0, P: *vector.New(1.5, 2), V: *vector.New(1, 0)},
+	}
+
+	testConfigs := []config{
+		{
+			name: "JSON",
+			f:    JSON,
+			succ: true,
+			want: `{"Tick":0,"T":0,"Agent":0,"P":[1,2],"V":[0,0]}
+{"Tick":1,"T":0.5,"Agent":0,"P":[1.5,2],"V":[1,0]}
+`,
+		},
+		{
+			name: "CSV",
+			f:    CSV,
+			succ: true,
+			want: `tick,t,agent,x,y,vx,vy
+0,0,0,1,2,0,0
+1,0.5,0,1.5,2,1,0
+`,
+		},
+		{
+			name: "Invalid",
+			f:    "gif",
+			succ: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			var b bytes.Buffer
+			w, err := New(&b, c.f)
+			if succ := err == nil; succ != c.succ {
+				t.Fatalf("New() returned error %v, want success = %v", err, c.succ)
+			}
+			if !c.succ {
+				return
+			}
+
+			for _, r := range rs {
+				if err := w.Write(r); err != nil {
+					t.Fatalf("Write() returned error %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() returned error %v", err)
+			}
+
+			if diff := cmp.Diff(c.want, b.String()); diff != "" {
+				t.Errorf("Write() mismatch (-want +got):\n%v", diff)
+			}
+
+			got, err := Read(&b, c.f)
+			if err != nil {
+				t.Fatalf("Read() returned error %v", err)
+			}
+			if diff := cmp.Diff(rs, got); diff != "" {
+				t.Errorf("Read() mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package simulator
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/line"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/external/snape/RVO2/solver"
+)
+
+var _ agent.A = &Agent{}
+
+// O is the set of agent parameters passed into RVOSimulator::addAgent.
+type O struct {
+	P vector.V
+	V vector.V
+	R float64
+
+	// S is the maximum speed of the agent.
+	S float64
+
+	// NeighborDist is the maximum distance from the agent at which other
+	// agents are considered for collision avoidance.
+	NeighborDist float64
+
+	// MaxNeighbors is the maximum number of other agents considered for
+	// collision avoidance.
+	MaxNeighbors int
+
+	// TimeHorizon is the lookahead time for agent-agent collisions.
+	TimeHorizon float64
+
+	// TimeHorizonObst is the lookahead time for agent-obstacle collisions.
+	TimeHorizonObst float64
+}
+
+type neighbor[T any] struct {
+	d float64
+	t T
+}
+
+// Agent is a transpilation of the RVO2 Agent class.
+type Agent struct {
+	o O
+
+	p vector.V
+	v vector.V
+
+	// t is the preferred velocity of the agent.
+	t vector.V
+
+	// u is the new velocity of the agent, as computed by
+	// computeNewVelocity. The new velocity is only applied to the agent
+	// after all agents have been processed.
+	u vector.V
+
+	agents    []neighbor[*Agent]
+	obstacles []neighbor[*obstacle]
+}
+
+func (a *Agent) P() vector.V { return a.p }
+func (a *Agent) V() vector.V { return a.v }
+func (a *Agent) R() float64  { return a.o.R }
+func (a *Agent) S() float64  { return a.o.S }
+func (a *Agent) T() vector.V { return a.t }
+
+// computeNeighbors finds the agent and obstacle neighbors of the agent.
+//
+// RVO2 accelerates the neighbor search with a pair of K-D trees; here we
+// search all agents and obstacles linearly, which returns the same neighbors
+// in the same order.
+func (a *Agent) computeNeighbors(s *Simulator) {
+	a.obstacles = a.obstacles[:0]
+	rangeSq := math.Pow(a.o.TimeHorizonObst*a.o.S+a.o.R, 2)
+	for _, o := range s.obstacles {
+		// The K-D tree only considers obstacle edges which are
+		// visible to the agent, i.e. edges for which the agent lies
+		// strictly to the right.
+		if leftOf(o.p, o.next.p, a.p) < 0 {
+			a.insertObstacleNeighbor(o, rangeSq)
+		}
+	}
+
+	a.agents = a.agents[:0]
+	if a.o.MaxNeighbors > 0 {
+		rangeSq = a.o.NeighborDist * a.o.NeighborDist
+		for _, b := range s.agents {
+			rangeSq = a.insertAgentNeighbor(b, rangeSq)
+		}
+	}
+}
+
+// insertAgentNeighbor inserts the input agent into the sorted list of agent
+// neighbors, and returns the updated search range. Once the list is full, the
+// search range shrinks to the distance of the furthest neighbor.
+func (a *Agent) insertAgentNeighbor(b *Agent, rangeSq float64) float64 {
+	if a == b {
+		return rangeSq
+	}
+
+	distSq := vector.SquaredMagnitude(vector.Sub(a.p, b.p))
+	if distSq >= rangeSq {
+		return rangeSq
+	}
+
+	if len(a.agents) < a.o.MaxNeighbors {
+		a.agents = append(a.agents, neighbor[*Agent]{d: distSq, t: b})
+	}
+	i := len(a.agents) - 1
+	for ; i != 0 && distSq < a.agents[i-1].d; i-- {
+		a.agents[i] = a.agents[i-1]
+	}
+	a.agents[i] = neighbor[*Agent]{d: distSq, t: b}
+
+	if len(a.agents) == a.o.MaxNeighbors {
+		rangeSq = a.agents[len(a.agents)-1].d
+	}
+	return rangeSq
+}
+
+func (a *Agent) insertObstacleNeighbor(o *obstacle, rangeSq float64) {
+	distSq := distSqPointLineSegment(o.p, o.next.p, a.p)
+	if distSq >= rangeSq {
+		return
+	}
+
+	a.obstacles = append(a.obstacles, neighbor[*obstacle]{d: distSq, t: o})
+	i := len(a.obstacles) - 1
+	for ; i != 0 && distSq < a.obstacles[i-1].d; i-- {
+		a.obstacles[i] = a.obstacles[i-1]
+	}
+	a.obstacles[i] = neighbor[*obstacle]{d: distSq, t: o}
+}
+
+// computeNewVelocity constructs the obstacle and agent ORCA lines of the
+// agent, and solves for the new velocity. Here dt is the simulation time step,
+// which is used to resolve existing agent-agent collisions.
+func (a *Agent) computeNewVelocity(dt float64) {
+	ls := a.obstacleLines()
+	n := len(ls)
+	ls = append(ls, a.agentLines(dt)...)
+
+	a.u = solver.Solve(ls, n, a.o.S, a.t)
+}
+
+// leg returns the left and right tangent directions from the agent to the
+// circle of radius r centered at the relative position p.
+func leg(p vector.V, r float64) (vector.V, vector.V) {
+	distSq := vector.SquaredMagnitude(p)
+	l := math.Sqrt(distSq - r*r)
+	return vector.Scale(1/distSq, *vector.New(p.X()*l-p.Y()*r, p.X()*r+p.Y()*l)),
+		vector.Scale(1/distSq, *vector.New(p.X()*l+p.Y()*r, -p.X()*r+p.Y()*l))
+}
+
+// neg returns the additive inverse of the input vector.
+func neg(v vector.V) vector.V { return vector.Scale(-1, v) }
+
+// obstacleLines constructs the ORCA lines for the obstacle neighbors of the
+// agent. Obstacle lines are never relaxed by the solver.
+func (a *Agent) obstacleLines() []line.L {
+	var ls []line.L
+
+	invTimeHorizonObst := 1 / a.o.TimeHorizonObst
+	radiusSq := a.o.R * a.o.R
+
+	// offset returns the point on the ORCA line with direction d which
+	// lies on the cut-off circle centered at c.
+	offset := func(c vector.V, d vector.V) vector.V {
+		return vector.Add(c, vector.Scale(a.o.R*invTimeHorizonObst, *vector.New(-d.Y(), d.X())))
+	}
+
+	for _, n := range a.obstacles {
+		obstacle1 := n.t
+		obstacle2 := obstacle1.next
+
+		relativePosition1 := vector.Sub(obstacle1.p, a.p)
+		relativePosition2 := vector.Sub(obstacle2.p, a.p)
+
+		// Check if velocity obstacle of obstacle is already taken care
+		// of by previously constructed obstacle ORCA lines.
+		alreadyCovered := false
+		for _, l := range ls {
+			if vector.Determinant(vector.Sub(vector.Scale(invTimeHorizonObst, relativePosition1), l.P()), l.D())-invTimeHorizonObst*a.o.R >= -epsilon &&
+				vector.Determinant(vector.Sub(vector.Scale(invTimeHorizonObst, relativePosition2), l.P()), l.D())-invTimeHorizonObst*a.o.R >= -epsilon {
+				alreadyCovered = true
+				break
+			}
+		}
+		if alreadyCovered {
+			continue
+		}
+
+		// Not yet covered. Check for collisions.
+		distSq1 := vector.SquaredMagnitude(relativePosition1)
+		distSq2 := vector.SquaredMagnitude(relativePosition2)
+
+		obstacleVector := vector.Sub(obstacle2.p, obstacle1.p)
+		s := vector.Dot(neg(relativePosition1), obstacleVector) / vector.SquaredMagnitude(obstacleVector)
+		distSqLine := vector.SquaredMagnitude(vector.Sub(neg(relativePosition1), vector.Scale(s, obstacleVector)))
+
+		if s < 0 && distSq1 <= radiusSq {
+			// Collision with left vertex. Ignore if non-convex.
+			if obstacle1.convex {
+				ls = append(ls, *line.New(
+					*vector.New(0, 0),
+					vector.Unit(*vector.New(-relativePosition1.Y(), relativePosition1.X())),
+				))
+			}
+			continue
+		} else if s > 1 && distSq2 <= radiusSq {
+			// Collision with right vertex. Ignore if non-convex or if
+			// it will be taken care of by the neighboring obstacle.
+			if obstacle2.convex && vector.Determinant(relativePosition2, obstacle2.d) >= 0 {
+				ls = append(ls, *line.New(
+					*vector.New(0, 0),
+					vector.Unit(*vector.New(-relativePosition2.Y(), relativePosition2.X())),
+				))
+			}
+			continue
+		} else if s >= 0 && s < 1 && distSqLine <= radiusSq {
+			// Collision with obstacle segment.
+			ls = append(ls, *line.New(*vector.New(0, 0), neg(obstacle1.d)))
+			continue
+		}
+
+		// No collision. Compute legs. When obliquely viewed, both legs
+		// can come from a single vertex. Legs extend the cut-off line
+		// when the vertex is non-convex.
+		var leftLegDirection, rightLegDirection vector.V
+
+		if s < 0 && distSqLine <= radiusSq {
+			// Obstacle viewed obliquely so that the left vertex
+			// defines the velocity obstacle.
+			if !obstacle1.convex {
+				continue
+			}
+			obstacle2 = obstacle1
+			leftLegDirection, rightLegDirection = leg(relativePosition1, a.o.R)
+		} else if s > 1 && distSqLine <= radiusSq {
+			// Obstacle viewed obliquely so that the right vertex
+			// defines the velocity obstacle.
+			if !obstacle2.convex {
+				continue
+			}
+			obstacle1 = obstacle2
+			leftLegDirection, rightLegDirection = leg(relativePosition2, a.o.R)
+		} else {
+			// Usual situation.
+			if obstacle1.convex {
+				leftLegDirection, _ = leg(relativePosition1, a.o.R)
+			} else {
+				// Left vertex non-convex; left leg extends
+				// cut-off line.
+				leftLegDirection = neg(obstacle1.d)
+			}
+
+			if obstacle2.convex {
+				_, rightLegDirection = leg(relativePosition2, a.o.R)
+			} else {
+				// Right vertex non-convex; right leg extends
+				// cut-off line.
+				rightLegDirection = obstacle1.d
+			}
+		}
+
+		// Legs can never point into a neighboring edge when the vertex
+		// is convex; take the cut-off line of the neighboring edge
+		// instead. If the velocity is projected on a "foreign" leg, no
+		// constraint is added.
+		leftNeighbor := obstacle1.prev
+
+		isLeftLegForeign := false
+		isRightLegForeign := false
+
+		if obstacle1.convex && vector.Determinant(leftLegDirection, neg(leftNeighbor.d)) >= 0 {
+			// Left leg points into obstacle.
+			leftLegDirection = neg(leftNeighbor.d)
+			isLeftLegForeign = true
+		}
+
+		if obstacle2.convex && vector.Determinant(rightLegDirection, obstacle2.d) <= 0 {
+			// Right leg points into obstacle.
+			rightLegDirection = obstacle2.d
+			isRightLegForeign = true
+		}
+
+		// Compute cut-off centers.
+		leftCutoff := vector.Scale(invTimeHorizonObst, vector.Sub(obstacle1.p, a.p))
+		rightCutoff := vector.Scale(invTimeHorizonObst, vector.Sub(obstacle2.p, a.p))
+		cutoffVec := vector.Sub(rightCutoff, leftCutoff)
+
+		// Project current velocity on velocity obstacle. Check if the
+		// current velocity is projected on the cut-off circles.
+		t := 0.5
+		if obstacle1 != obstacle2 {
+			t = vector.Dot(vector.Sub(a.v, leftCutoff), cutoffVec) / vector.SquaredMagnitude(cutoffVec)
+		}
+		tLeft := vector.Dot(vector.Sub(a.v, leftCutoff), leftLegDirection)
+		tRight := vector.Dot(vector.Sub(a.v, rightCutoff), rightLegDirection)
+
+		if (t < 0 && tLeft < 0) || (obstacle1 == obstacle2 && tLeft < 0 && tRight < 0) {
+			// Project on left cut-off circle.
+			unitW := vector.Unit(vector.Sub(a.v, leftCutoff))
+			ls = append(ls, *line.New(
+				vector.Add(leftCutoff, vector.Scale(a.o.R*invTimeHorizonObst, unitW)),
+				*vector.New(unitW.Y(), -unitW.X()),
+			))
+			continue
+		} else if t > 1 && tRight < 0 {
+			// Project on right cut-off circle.
+			unitW := vector.Unit(vector.Sub(a.v, rightCutoff))
+			ls = append(ls, *line.New(
+				vector.Add(rightCutoff, vector.Scale(a.o.R*invTimeHorizonObst, unitW)),
+				*vector.New(unitW.Y(), -unitW.X()),
+			))
+			continue
+		}
+
+		// Project on left leg, right leg, or cut-off line, whichever is
+		// closest to the velocity.
+		distSqCutoff := math.Inf(1)
+		if !(t < 0 || t > 1 || obstacle1 == obstacle2) {
+			distSqCutoff = vector.SquaredMagnitude(vector.Sub(a.v, vector.Add(leftCutoff, vector.Scale(t, cutoffVec))))
+		}
+		distSqLeft := math.Inf(1)
+		if !(tLeft < 0) {
+			distSqLeft = vector.SquaredMagnitude(vector.Sub(a.v, vector.Add(leftCutoff, vector.Scale(tLeft, leftLegDirection))))
+		}
+		distSqRight := math.Inf(1)
+		if !(tRight < 0) {
+			distSqRight = vector.SquaredMagnitude(vector.Sub(a.v, vector.Add(rightCutoff, vector.Scale(tRight, rightLegDirection))))
+		}
+
+		if distSqCutoff <= distSqLeft && distSqCutoff <= distSqRight {
+			// Project on cut-off line.
+			d := neg(obstacle1.d)
+			ls = append(ls, *line.New(offset(leftCutoff, d), d))
+		} else if distSqLeft <= distSqRight {
+			// Project on left leg.
+			if isLeftLegForeign {
+				continue
+			}
+			ls = append(ls, *line.New(offset(leftCutoff, leftLegDirection), leftLegDirection))
+		} else {
+			// Project on right leg.
+			if isRightLegForeign {
+				continue
+			}
+			d := neg(rightLegDirection)
+			ls = append(ls, *line.New(offset(rightCutoff, d), d))
+		}
+	}
+
+	return ls
+}
+
+// agentLines constructs the reciprocal ORCA lines for the agent neighbors of
+// the agent. Agents which already collide are pushed apart within a single
+// time step dt.
+func (a *Agent) agentLines(dt float64) []line.L {
+	ls := make([]line.L, 0, len(a.agents))
+
+	invTimeHorizon := 1 / a.o.TimeHorizon
+
+	for _, n := range a.agents {
+		other := n.t
+
+		relativePosition := vector.Sub(other.p, a.p)
+		relativeVelocity := vector.Sub(a.v, other.v)
+		distSq := vector.SquaredMagnitude(relativePosition)
+		combinedRadius := a.o.R + other.o.R
+		combinedRadiusSq := combinedRadius * combinedRadius
+
+		var d vector.V
+		var u vector.V
+
+		if distSq > combinedRadiusSq {
+			// No collision.
+			w := vector.Sub(relativeVelocity, vector.Scale(invTimeHorizon, relativePosition))
+
+			// Vector from cutoff center to relative velocity.
+			wLengthSq := vector.SquaredMagnitude(w)
+			dotProduct1 := vector.Dot(w, relativePosition)
+
+			if dotProduct1 < 0 && dotProduct1*dotProduct1 > combinedRadiusSq*wLengthSq {
+				// Project on cut-off circle.
+				wLength := math.Sqrt(wLengthSq)
+				unitW := vector.Scale(1/wLength, w)
+
+				d = *vector.New(unitW.Y(), -unitW.X())
+				u = vector.Scale(combinedRadius*invTimeHorizon-wLength, unitW)
+			} else {
+				// Project on legs.
+				l, r := leg(relativePosition, combinedRadius)
+				if vector.Determinant(relativePosition, w) > 0 {
+					// Project on left leg.
+					d = l
+				} else {
+					// Project on right leg.
+					d = neg(r)
+				}
+
+				dotProduct2 := vector.Dot(relativeVelocity, d)
+				u = vector.Sub(vector.Scale(dotProduct2, d), relativeVelocity)
+			}
+		} else {
+			// Collision. Project on cut-off circle of time step.
+			invTimeStep := 1 / dt
+
+			// Vector from cutoff center to relative velocity.
+			w := vector.Sub(relativeVelocity, vector.Scale(invTimeStep, relativePosition))
+
+			wLength := vector.Magnitude(w)
+			unitW := vector.Scale(1/wLength, w)
+
+			d = *vector.New(unitW.Y(), -unitW.X())
+			u = vector.Scale(combinedRadius*invTimeStep-wLength, unitW)
+		}
+
+		ls = append(ls, *line.New(vector.Add(a.v, vector.Scale(0.5, u)), d))
+	}
+
+	return ls
+}
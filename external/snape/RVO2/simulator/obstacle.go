@@ -0,0 +1,45 @@
+package simulator
+
+import (
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+// obstacle is a single vertex of a polygonal obstacle, and corresponds to the
+// RVO2 Obstacle class. The vertex also represents the obstacle edge from p to
+// next.p.
+type obstacle struct {
+	p vector.V
+
+	// d is the unit direction of the obstacle edge, i.e. from p to next.p.
+	d vector.V
+
+	convex bool
+
+	next *obstacle
+	prev *obstacle
+
+	id int
+}
+
+// leftOf computes the signed distance of c from the line connecting a and b,
+// scaled by the length of the segment ab. The value is positive if c lies to
+// the left of the line.
+func leftOf(a vector.V, b vector.V, c vector.V) float64 {
+	return vector.Determinant(vector.Sub(a, c), vector.Sub(b, a))
+}
+
+// distSqPointLineSegment computes the squared distance from c to the line
+// segment ab.
+func distSqPointLineSegment(a vector.V, b vector.V, c vector.V) float64 {
+	ab := vector.Sub(b, a)
+	r := vector.Dot(vector.Sub(c, a), ab) / vector.SquaredMagnitude(ab)
+
+	switch {
+	case r < 0:
+		return vector.SquaredMagnitude(vector.Sub(c, a))
+	case r > 1:
+		return vector.SquaredMagnitude(vector.Sub(c, b))
+	default:
+		return vector.SquaredMagnitude(vector.Sub(c, vector.Add(a, vector.Scale(r, ab))))
+	}
+}
@@ -0,0 +1,120 @@
+// Package simulator is a transpilation of the simulation step loop in the
+// official RVO2 implementation, i.e. RVOSimulator::doStep, and the neighbor
+// search and velocity update of the Agent class in
+// https://github.com/snape/RVO2/blob/57098835aa27dda6d00c43fc0800f621724884cc/src/.
+//
+// Agent ORCA lines are solved via the transpiled linear programs in
+// external/snape/RVO2/solver.
+//
+// N.B.: RVO2 accelerates neighbor searches with an agent K-D tree and an
+// obstacle BSP tree; the BSP tree additionally splits obstacle edges which
+// straddle a splitting line. The transpilation instead searches all agents
+// and obstacle edges linearly, and does not split obstacles.
+package simulator
+
+import (
+	"github.com/downflux/go-geometry/2d/vector"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// epsilon is RVO_EPSILON.
+	epsilon = 1e-5
+)
+
+// Simulator is a transpilation of the RVO2 RVOSimulator class.
+type Simulator struct {
+	agents    []*Agent
+	obstacles []*obstacle
+
+	// dt is the simulation time step.
+	dt float64
+
+	// t is the global simulation time.
+	t float64
+}
+
+func New(dt float64) *Simulator {
+	return &Simulator{dt: dt}
+}
+
+// AddAgent adds a new agent to the simulation, and returns the index of the
+// agent. The preferred velocity of the agent is initialized to zero.
+func (s *Simulator) AddAgent(o O) int {
+	v := o.V
+	if v == nil {
+		v = *vector.New(0, 0)
+	}
+	s.agents = append(s.agents, &Agent{
+		o: o,
+		p: o.P,
+		v: v,
+		t: *vector.New(0, 0),
+	})
+	return len(s.agents) - 1
+}
+
+// AddObstacle adds a new polygonal obstacle to the simulation, and returns the
+// index of the first vertex of the obstacle.
+//
+// The vertices must be listed in counterclockwise order; a list of two
+// vertices represents a double-sided line segment.
+func (s *Simulator) AddObstacle(vs []vector.V) (int, error) {
+	if len(vs) < 2 {
+		return 0, status.Errorf(codes.InvalidArgument, "an obstacle must have at least two vertices, but got %v", len(vs))
+	}
+
+	n := len(s.obstacles)
+	for i := range vs {
+		next := vs[(i+1)%len(vs)]
+		prev := vs[(i+len(vs)-1)%len(vs)]
+
+		o := &obstacle{
+			p:  vs[i],
+			d:  vector.Unit(vector.Sub(next, vs[i])),
+			id: len(s.obstacles),
+		}
+		if i != 0 {
+			o.prev = s.obstacles[len(s.obstacles)-1]
+			o.prev.next = o
+		}
+		if i == len(vs)-1 {
+			o.next = s.obstacles[n]
+			o.next.prev = o
+		}
+
+		o.convex = len(vs) == 2 || leftOf(prev, vs[i], next) >= 0
+
+		s.obstacles = append(s.obstacles, o)
+	}
+	return n, nil
+}
+
+// Agent returns the i-th agent of the simulation.
+func (s *Simulator) Agent(i int) *Agent { return s.agents[i] }
+
+// N returns the number of agents in the simulation.
+func (s *Simulator) N() int { return len(s.agents) }
+
+// T returns the global simulation time.
+func (s *Simulator) T() float64 { return s.t }
+
+// SetT sets the preferred velocity of the i-th agent. The preferred velocity
+// is not updated by the simulation, and should be set by the caller before
+// each step.
+func (s *Simulator) SetT(i int, v vector.V) { s.agents[i].t = v }
+
+// Step computes the new velocities of all agents, and then moves all agents
+// with their new velocities over a single time step.
+func (s *Simulator) Step() {
+	for _, a := range s.agents {
+		a.computeNeighbors(s)
+		a.computeNewVelocity(s.dt)
+	}
+	for _, a := range s.agents {
+		a.v = a.u
+		a.p = vector.Add(a.p, vector.Scale(s.dt, a.v))
+	}
+	s.t += s.dt
+}
@@ -0,0 +1,144 @@
+package simulator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+func TestAddObstacle(t *testing.T) {
+	type config struct {
+		name   string
+		vs     []vector.V
+		succ   bool
+		convex []bool
+	}
+
+	testConfigs := []config{
+		{
+			name: "Point",
+			vs:   []vector.V{*vector.New(0, 0)},
+			succ: false,
+		},
+		{
+			name:   "Segment",
+			vs:     []vector.V{*vector.New(0, 0), *vector.New(1, 0)},
+			succ:   true,
+			convex: []bool{true, true},
+		},
+		{
+			name: "Concave",
+			vs: []vector.V{
+				*vector.New(0, 0),
+				*vector.New(2, 0),
+				*vector.New(1, 1),
+				*vector.New(2, 2),
+				*vector.New(0, 2),
+			},
+			succ:   true,
+			convex: []bool{true, true, false, true, true},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			s := New(1)
+			_, err := s.AddObstacle(c.vs)
+			if succ := err == nil; succ != c.succ {
+				t.Fatalf("AddObstacle() returned error %v, want success = %v", err, c.succ)
+			}
+			for i, o := range s.obstacles {
+				if o.convex != c.convex[i] {
+					t.Errorf("convex[%v] = %v, want = %v", i, o.convex, c.convex[i])
+				}
+				if o.next.prev != o || o.prev.next != o {
+					t.Errorf("obstacle %v is not linked to its neighbors", i)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeNeighbors(t *testing.T) {
+	s := New(1)
+	for _, x := range []float64{0, 3, 1, 2, 10} {
+		s.AddAgent(O{
+			P:               *vector.New(x, 0),
+			R:               0.1,
+			S:               1,
+			NeighborDist:    5,
+			MaxNeighbors:    2,
+			TimeHorizon:     1,
+			TimeHorizonObst: 1,
+		})
+	}
+	a := s.Agent(0)
+	a.computeNeighbors(s)
+
+	want := []float64{1, 2}
+	if len(a.agents) != len(want) {
+		t.Fatalf("len(agents) = %v, want = %v", len(a.agents), len(want))
+	}
+	for i, n := range a.agents {
+		if got := n.t.P().X(); got != want[i] {
+			t.Errorf("agents[%v].P().X() = %v, want = %v", i, got, want[i])
+		}
+	}
+}
+
+func TestStep(t *testing.T) {
+	const (
+		dt = 0.1
+		n  = 100
+	)
+
+	t.Run("HeadOn", func(t *testing.T) {
+		s := New(dt)
+		for _, x := range []float64{-5, 5} {
+			s.AddAgent(O{
+				P:               *vector.New(x, 0.01),
+				R:               1,
+				S:               1,
+				NeighborDist:    10,
+				MaxNeighbors:    10,
+				TimeHorizon:     5,
+				TimeHorizonObst: 5,
+			})
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < s.N(); j++ {
+				s.SetT(j, *vector.New(-math.Copysign(1, s.Agent(j).P().X()), 0))
+			}
+			s.Step()
+
+			if d := vector.Magnitude(vector.Sub(s.Agent(0).P(), s.Agent(1).P())); d < 2-1e-5 {
+				t.Fatalf("agents collided at t = %v, with separation %v", s.T(), d)
+			}
+		}
+	})
+
+	t.Run("Wall", func(t *testing.T) {
+		s := New(dt)
+		s.AddAgent(O{
+			P:               *vector.New(0, 0),
+			R:               1,
+			S:               1,
+			NeighborDist:    10,
+			MaxNeighbors:    10,
+			TimeHorizon:     5,
+			TimeHorizonObst: 5,
+		})
+		if _, err := s.AddObstacle([]vector.V{*vector.New(-5, 3), *vector.New(5, 3)}); err != nil {
+			t.Fatalf("AddObstacle() returned error %v", err)
+		}
+		for i := 0; i < n; i++ {
+			s.SetT(0, *vector.New(0, 1))
+			s.Step()
+
+			if y := s.Agent(0).P().Y(); y > 2+1e-5 {
+				t.Fatalf("agent penetrated wall at t = %v, with position %v", s.T(), s.Agent(0).P())
+			}
+		}
+	})
+}
@@ -0,0 +1,201 @@
+// Package solver is a transpilation of the linear programming solvers in the
+// official RVO2 implementation, i.e. Agent::linearProgram1, linearProgram2, and
+// linearProgram3 in
+// https://github.com/snape/RVO2/blob/57098835aa27dda6d00c43fc0800f621724884cc/src/Agent.cpp.
+//
+// RVO2 represents a constraint as a directed line with a unit direction
+// vector, where the feasible region lies to the left of the line. Use L to
+// convert a hyperplane into this representation.
+//
+// N.B.: RVO2 uses single precision floating point arithmetic, whereas the
+// transpilation uses double precision.
+package solver
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/line"
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+const (
+	// epsilon is RVO_EPSILON, the threshold below which two lines are
+	// considered parallel.
+	epsilon = 1e-5
+)
+
+// L converts the input hyperplane into a constraint line in the RVO2
+// convention.
+func L(hp hyperplane.HP) line.L {
+	return *line.New(hp.P(), vector.Unit(*vector.New(hp.N().Y(), -hp.N().X())))
+}
+
+// LinearProgram1 solves a one-dimensional linear program on the i-th line,
+// subject to the constraints of all previous lines and the maximum speed
+// circle of radius r. LinearProgram1 returns false if the program is
+// infeasible.
+//
+// If directionOpt is set, the solution is the extreme point on the line in the
+// direction of v; otherwise, the solution is the point on the line closest to
+// v.
+func LinearProgram1(ls []line.L, i int, r float64, v vector.V, directionOpt bool) (vector.V, bool) {
+	dotProduct := vector.Dot(ls[i].P(), ls[i].D())
+	discriminant := dotProduct*dotProduct + r*r - vector.SquaredMagnitude(ls[i].P())
+
+	if discriminant < 0 {
+		// Max speed circle fully invalidates line i.
+		return vector.V{}, false
+	}
+
+	sqrtDiscriminant := math.Sqrt(discriminant)
+	tLeft := -dotProduct - sqrtDiscriminant
+	tRight := -dotProduct + sqrtDiscriminant
+
+	for j := 0; j < i; j++ {
+		denominator := vector.Determinant(ls[i].D(), ls[j].D())
+		numerator := vector.Determinant(ls[j].D(), vector.Sub(ls[i].P(), ls[j].P()))
+
+		if math.Abs(denominator) <= epsilon {
+			// Lines i and j are (almost) parallel.
+			if numerator < 0 {
+				return vector.V{}, false
+			}
+			continue
+		}
+
+		t := numerator / denominator
+
+		if denominator >= 0 {
+			// Line j bounds line i on the right.
+			tRight = math.Min(tRight, t)
+		} else {
+			// Line j bounds line i on the left.
+			tLeft = math.Max(tLeft, t)
+		}
+
+		if tLeft > tRight {
+			return vector.V{}, false
+		}
+	}
+
+	if directionOpt {
+		// Optimize direction.
+		if vector.Dot(v, ls[i].D()) > 0 {
+			// Take right extreme.
+			return ls[i].L(tRight), true
+		}
+		// Take left extreme.
+		return ls[i].L(tLeft), true
+	}
+
+	// Optimize closest point.
+	t := vector.Dot(ls[i].D(), vector.Sub(v, ls[i].P()))
+	if t < tLeft {
+		return ls[i].L(tLeft), true
+	}
+	if t > tRight {
+		return ls[i].L(tRight), true
+	}
+	return ls[i].L(t), true
+}
+
+// LinearProgram2 solves a two-dimensional linear program subject to the input
+// lines and the maximum speed circle of radius r.
+//
+// LinearProgram2 returns the solution and the number of lines processed. If
+// the returned count is smaller than len(ls), the program is infeasible, and
+// the solution is the partial solution before processing the failing line.
+//
+// If directionOpt is set, v must be of unit length.
+func LinearProgram2(ls []line.L, r float64, v vector.V, directionOpt bool) (vector.V, int) {
+	var result vector.V
+	if directionOpt {
+		// Optimize direction. Note that the optimization velocity is
+		// of unit length in this case.
+		result = vector.Scale(r, v)
+	} else if vector.SquaredMagnitude(v) > r*r {
+		// Optimize closest point and outside circle.
+		result = vector.Scale(r, vector.Unit(v))
+	} else {
+		// Optimize closest point and inside circle.
+		result = v
+	}
+
+	for i := range ls {
+		if vector.Determinant(ls[i].D(), vector.Sub(ls[i].P(), result)) > 0 {
+			// Result does not satisfy constraint i. Compute new
+			// optimal result.
+			u, ok := LinearProgram1(ls, i, r, v, directionOpt)
+			if !ok {
+				return result, i
+			}
+			result = u
+		}
+	}
+
+	return result, len(ls)
+}
+
+// LinearProgram3 finds the velocity which minimizes the maximum penetration
+// into the constraint lines, given the partial solution result of
+// LinearProgram2, which failed at line begin.
+//
+// The first n lines are obstacle lines, which are never relaxed.
+func LinearProgram3(ls []line.L, n int, begin int, r float64, result vector.V) vector.V {
+	distance := 0.0
+
+	for i := begin; i < len(ls); i++ {
+		if vector.Determinant(ls[i].D(), vector.Sub(ls[i].P(), result)) > distance {
+			// Result does not satisfy constraint of line i.
+			projLines := make([]line.L, n, len(ls))
+			copy(projLines, ls[:n])
+
+			for j := n; j < i; j++ {
+				var p vector.V
+
+				determinant := vector.Determinant(ls[i].D(), ls[j].D())
+
+				if math.Abs(determinant) <= epsilon {
+					// Line i and line j are parallel.
+					if vector.Dot(ls[i].D(), ls[j].D()) > 0 {
+						// Line i and line j point in the
+						// same direction.
+						continue
+					}
+					// Line i and line j point in opposite
+					// direction.
+					p = vector.Scale(0.5, vector.Add(ls[i].P(), ls[j].P()))
+				} else {
+					p = ls[i].L(vector.Determinant(ls[j].D(), vector.Sub(ls[i].P(), ls[j].P())) / determinant)
+				}
+
+				projLines = append(projLines, *line.New(p, vector.Unit(vector.Sub(ls[j].D(), ls[i].D()))))
+			}
+
+			// This should in principle not fail. The result is by
+			// definition already in the feasible region of this
+			// linear program. If it fails, it is due to small
+			// floating point error, and the current result is kept.
+			if u, k := LinearProgram2(projLines, r, *vector.New(-ls[i].D().Y(), ls[i].D().X()), true); k == len(projLines) {
+				result = u
+			}
+
+			distance = vector.Determinant(ls[i].D(), vector.Sub(ls[i].P(), result))
+		}
+	}
+	return result
+}
+
+// Solve finds the velocity closest to v which satisfies all input lines and
+// the maximum speed circle of radius r, falling back to LinearProgram3 if the
+// program is infeasible. The first n lines are obstacle lines.
+//
+// This is the solver invoked by Agent::computeNewVelocity.
+func Solve(ls []line.L, n int, r float64, v vector.V) vector.V {
+	result, i := LinearProgram2(ls, r, v, false)
+	if i < len(ls) {
+		result = LinearProgram3(ls, n, i, r, result)
+	}
+	return result
+}
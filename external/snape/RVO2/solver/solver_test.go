@@ -0,0 +1,97 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/line"
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+func TestL(t *testing.T) {
+	hp := *hyperplane.New(*vector.New(0, 1), *vector.New(0, 2))
+	l := L(hp)
+
+	if want := *vector.New(1, 0); !vector.Within(l.D(), want) {
+		t.Errorf("D() = %v, want = %v", l.D(), want)
+	}
+	// RVO2 lines are feasible to the left of the line direction.
+	if got := vector.Determinant(l.D(), vector.Sub(l.P(), *vector.New(0, 2))); got > 0 {
+		t.Errorf("Determinant() = %v, want <= 0", got)
+	}
+}
+
+func TestLinearProgram2(t *testing.T) {
+	type config struct {
+		name string
+		ls   []line.L
+		r    float64
+		v    vector.V
+		want vector.V
+		n    int
+	}
+
+	testConfigs := []config{
+		{
+			name: "Unconstrained",
+			ls:   nil,
+			r:    10,
+			v:    *vector.New(3, 4),
+			want: *vector.New(3, 4),
+			n:    0,
+		},
+		{
+			name: "Unconstrained/Clamp",
+			ls:   nil,
+			r:    1,
+			v:    *vector.New(3, 4),
+			want: *vector.New(0.6, 0.8),
+			n:    0,
+		},
+		{
+			name: "Constrained",
+			ls: []line.L{
+				L(*hyperplane.New(*vector.New(0, 1), *vector.New(0, -1))),
+			},
+			r:    10,
+			v:    *vector.New(3, 4),
+			want: *vector.New(3, 1),
+			n:    1,
+		},
+		// The partial solution is the solution before processing the
+		// first infeasible line.
+		{
+			name: "Infeasible",
+			ls: []line.L{
+				L(*hyperplane.New(*vector.New(0, 1), *vector.New(0, -1))),
+				L(*hyperplane.New(*vector.New(0, 2), *vector.New(0, 1))),
+			},
+			r:    10,
+			v:    *vector.New(3, 4),
+			want: *vector.New(3, 1),
+			n:    1,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got, n := LinearProgram2(c.ls, c.r, c.v, false)
+			if n != c.n || !vector.Within(got, c.want) {
+				t.Errorf("LinearProgram2() = %v, %v, want = %v, %v", got, n, c.want, c.n)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// The two lines do not overlap. The solution minimizes the maximum
+	// penetration distance into the two lines, and lies on the line
+	// x = 0.
+	ls := []line.L{
+		L(*hyperplane.New(*vector.New(1, 0), *vector.New(1, 0))),
+		L(*hyperplane.New(*vector.New(-1, 0), *vector.New(-1, 0))),
+	}
+	if got, want := Solve(ls, 0, 2, *vector.New(0, 0)), *vector.New(0, 2); !vector.Within(got, want) {
+		t.Errorf("Solve() = %v, want = %v", got, want)
+	}
+}
@@ -78,7 +78,7 @@ func (vo Reference) u() (vector.V, error) {
 	case domain.Collision:
 		fallthrough
 	case domain.Circle:
-		tr := vo.r()
+		tr := vo.r() / vo.tau
 		tw := vo.w()
 
 		if d == domain.Collision {
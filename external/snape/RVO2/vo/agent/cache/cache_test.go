@@ -93,6 +93,21 @@ func TestVOReference(t *testing.T) {
 				*vector.New(-0.8, -0.6),
 			),
 		},
+		// The truncation circle is centered at p / 𝜏 = (0, 2.5) with
+		// radius r / 𝜏 = 1.5; the nearest point on the circle to the
+		// relative velocity (0, 2) is (0, 1).
+		{
+			name:     "CircleLargeTau",
+			agent:    a,
+			obstacle: *agent.New(agent.O{P: *vector.New(0, 5), V: *vector.New(0, -2), R: 2}),
+			tau:      2,
+			domain:   domain.Circle,
+			u:        *vector.New(0, -1),
+			orca: *hyperplane.New(
+				*vector.New(0, -0.5),
+				*vector.New(0, -1),
+			),
+		},
 		{
 			name:     "InverseSimple",
 			agent:    b,
@@ -6,6 +6,7 @@ require (
 	github.com/downflux/go-geometry v0.13.1
 	github.com/downflux/go-kd v1.0.4
 	github.com/google/go-cmp v0.5.9
+	google.golang.org/genproto v0.0.0-20221018160656-63c7b68cfc55
 	google.golang.org/grpc v1.50.1
 )
 
@@ -15,6 +16,5 @@ require (
 	golang.org/x/net v0.1.0 // indirect
 	golang.org/x/sys v0.1.0 // indirect
 	golang.org/x/text v0.4.0 // indirect
-	google.golang.org/genproto v0.0.0-20221018160656-63c7b68cfc55 // indirect
 	google.golang.org/protobuf v1.28.1 // indirect
 )
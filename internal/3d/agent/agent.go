@@ -0,0 +1,25 @@
+package agent
+
+import (
+	"github.com/downflux/go-geometry/3d/vector"
+)
+
+type O struct {
+	P vector.V
+	V vector.V
+	R float64
+	S float64
+	T vector.V
+}
+
+type A struct {
+	o O
+}
+
+func New(o O) *A { return &A{o: o} }
+
+func (a A) P() vector.V { return a.o.P }
+func (a A) R() float64  { return a.o.R }
+func (a A) V() vector.V { return a.o.V }
+func (a A) T() vector.V { return a.o.T }
+func (a A) S() float64  { return a.o.S }
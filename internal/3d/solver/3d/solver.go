@@ -0,0 +1,199 @@
+// Package solver solves a 3D linear programming problem in 3D ambient space,
+// where the solution is additionally bounded by a sphere centered at the
+// origin (i.e. the maximum speed of an agent).
+//
+// This is a port of linearProgram1, linearProgram2, and linearProgram3 in the
+// official RVO2-3D implementation. As in the 2D case, constraints are added
+// incrementally -- if the current solution violates a new constraint, the new
+// optimal solution must lie on the new constraint plane, and we solve the
+// lower-dimensional problem on the plane, which in turn may need to be solved
+// on the line of intersection between two planes.
+package solver
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/3d/vector"
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-orca/internal/solver/feasibility"
+
+	vnd "github.com/downflux/go-geometry/nd/vector"
+)
+
+const (
+	// tolerance is the threshold under which we consider vectors to be
+	// degenerate, and matches the RVO_EPSILON value in RVO2-3D.
+	tolerance = 1e-5
+)
+
+// O specifies the optimization target of the linear programming problem.
+type O int
+
+const (
+	// Distance minimizes the distance between the solution and the input
+	// target vector.
+	Distance O = iota
+
+	// Direction maximizes the projection of the solution onto the input
+	// target vector, which is assumed to be a unit vector.
+	Direction
+)
+
+// Solve attempts to calculate a solution to the linear programming problem
+// which satisfies all input constraints, lies within a sphere of radius r, and
+// optimizes the given target vector v per the optimization mode o. Solve will
+// return a partial feasibility if there is no such solution; the returned
+// vector in this case is the last solution which satisfied all constraints
+// prior to the infeasible constraint.
+//
+// This is analogous to linearProgram3 in the official RVO2-3D implementation.
+func Solve(cs []hyperplane.HP, r float64, v vector.V, o O) (vector.V, feasibility.F) {
+	var u vector.V
+	switch {
+	case o == Direction:
+		u = vector.Scale(r, v)
+	case vector.SquaredMagnitude(v) > r*r:
+		u = vector.Scale(r, vector.Unit(v))
+	default:
+		u = v
+	}
+
+	for i, c := range cs {
+		if !c.In(vnd.V(u)) {
+			w, ok := plane(cs[:i], c, r, v, o)
+			if !ok {
+				return u, feasibility.Partial
+			}
+			u = w
+		}
+	}
+	return u, feasibility.Feasible
+}
+
+// plane finds the optimal solution on the input constraint plane c, which
+// satisfies all previous constraints cs.
+//
+// This is analogous to linearProgram2 in the official RVO2-3D implementation.
+func plane(cs []hyperplane.HP, c hyperplane.HP, r float64, v vector.V, o O) (vector.V, bool) {
+	n := vector.V(c.N())
+	p := vector.V(c.P())
+
+	// d is the distance from the origin to the constraint plane.
+	d := vector.Dot(p, n)
+	if d*d > r*r {
+		return nil, false
+	}
+
+	// The intersection of the bounding sphere and the constraint plane is
+	// a circle of radius √(r² - d²) centered at dn.
+	s := r*r - d*d
+	center := vector.Scale(d, n)
+
+	var u vector.V
+	if o == Direction {
+		// Project the direction onto the plane.
+		w := vector.Sub(v, vector.Scale(vector.Dot(v, n), n))
+		if l := vector.SquaredMagnitude(w); l <= tolerance {
+			u = center
+		} else {
+			u = vector.Add(center, vector.Scale(math.Sqrt(s/l), w))
+		}
+	} else {
+		// Project the target onto the plane, and clamp the result to
+		// the bounding circle.
+		u = vector.Add(v, vector.Scale(vector.Dot(vector.Sub(p, v), n), n))
+		if vector.SquaredMagnitude(u) > r*r {
+			w := vector.Sub(u, center)
+			u = vector.Add(center, vector.Scale(math.Sqrt(s/vector.SquaredMagnitude(w)), w))
+		}
+	}
+
+	for i, e := range cs {
+		if e.In(vnd.V(u)) {
+			continue
+		}
+
+		// The optimal solution lies on the line of intersection between
+		// the two planes.
+		m := vector.V(e.N())
+		x := vector.Cross(m, n)
+
+		// The planes are parallel, and the previous constraint
+		// invalidates the current constraint.
+		if vector.SquaredMagnitude(x) <= tolerance {
+			return nil, false
+		}
+
+		dir := vector.Unit(x)
+
+		// l is the direction on the current constraint plane which is
+		// perpendicular to the line of intersection.
+		l := vector.Cross(dir, n)
+		q := vector.Add(
+			p,
+			vector.Scale(
+				vector.Dot(vector.Sub(vector.V(e.P()), p), m)/vector.Dot(l, m),
+				l,
+			),
+		)
+
+		w, ok := line(cs[:i], q, dir, r, v, o)
+		if !ok {
+			return nil, false
+		}
+		u = w
+	}
+	return u, true
+}
+
+// line finds the optimal solution on the input line L := q + td which satisfies
+// all previous constraints cs.
+//
+// This is analogous to linearProgram1 in the official RVO2-3D implementation.
+func line(cs []hyperplane.HP, q vector.V, d vector.V, r float64, v vector.V, o O) (vector.V, bool) {
+	dot := vector.Dot(q, d)
+	disc := dot*dot + r*r - vector.SquaredMagnitude(q)
+
+	// The line lies outside the bounding sphere.
+	if disc < 0 {
+		return nil, false
+	}
+
+	tmin := -dot - math.Sqrt(disc)
+	tmax := -dot + math.Sqrt(disc)
+
+	for _, c := range cs {
+		n := vector.V(c.N())
+		num := vector.Dot(vector.Sub(vector.V(c.P()), q), n)
+		den := vector.Dot(d, n)
+
+		// The line is (nearly) parallel to the constraint plane.
+		if den*den <= tolerance {
+			if num > 0 {
+				return nil, false
+			}
+			continue
+		}
+
+		t := num / den
+		if den >= 0 {
+			tmin = math.Max(tmin, t)
+		} else {
+			tmax = math.Min(tmax, t)
+		}
+
+		if tmin > tmax {
+			return nil, false
+		}
+	}
+
+	if o == Direction {
+		if vector.Dot(v, d) > 0 {
+			return vector.Add(q, vector.Scale(tmax, d)), true
+		}
+		return vector.Add(q, vector.Scale(tmin, d)), true
+	}
+
+	t := math.Max(tmin, math.Min(tmax, vector.Dot(d, vector.Sub(v, q))))
+	return vector.Add(q, vector.Scale(t, d)), true
+}
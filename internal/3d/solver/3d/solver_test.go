@@ -0,0 +1,132 @@
+package solver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/3d/vector"
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-orca/internal/solver/feasibility"
+
+	vnd "github.com/downflux/go-geometry/nd/vector"
+)
+
+func TestSolve(t *testing.T) {
+	type config struct {
+		name    string
+		cs      []hyperplane.HP
+		r       float64
+		v       vector.V
+		o       O
+		success feasibility.F
+		want    vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name:    "NoConstraints",
+			r:       10,
+			v:       *vector.New(1, 2, 3),
+			o:       Distance,
+			success: feasibility.Feasible,
+			want:    *vector.New(1, 2, 3),
+		},
+		{
+			name:    "NoConstraints/TooFast",
+			r:       1,
+			v:       *vector.New(0, 0, 2),
+			o:       Distance,
+			success: feasibility.Feasible,
+			want:    *vector.New(0, 0, 1),
+		},
+		{
+			name:    "NoConstraints/Direction",
+			r:       2,
+			v:       *vector.New(0, 1, 0),
+			o:       Direction,
+			success: feasibility.Feasible,
+			want:    *vector.New(0, 2, 0),
+		},
+		{
+			name: "Plane",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 1), *vnd.New(0, 0, -1)),
+			},
+			r:       10,
+			v:       *vector.New(1, 2, 3),
+			o:       Distance,
+			success: feasibility.Feasible,
+			want:    *vector.New(1, 2, 1),
+		},
+		// The plane z <= 1 intersects the bounding sphere in a circle
+		// of radius √3; the projected target (0, 5, 1) is clamped to
+		// this circle.
+		{
+			name: "Plane/Bounded",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 1), *vnd.New(0, 0, -1)),
+			},
+			r:       2,
+			v:       *vector.New(0, 5, 3),
+			o:       Distance,
+			success: feasibility.Feasible,
+			want:    *vector.New(0, math.Sqrt(3), 1),
+		},
+		{
+			name: "Line",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 1), *vnd.New(0, 0, -1)),
+				*hyperplane.New(*vnd.New(0, 1, 0), *vnd.New(0, -1, 0)),
+			},
+			r:       10,
+			v:       *vector.New(1, 2, 3),
+			o:       Distance,
+			success: feasibility.Feasible,
+			want:    *vector.New(1, 1, 1),
+		},
+		{
+			name: "Point",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 1), *vnd.New(0, 0, -1)),
+				*hyperplane.New(*vnd.New(0, 1, 0), *vnd.New(0, -1, 0)),
+				*hyperplane.New(*vnd.New(-1, 0, 0), *vnd.New(-1, 0, 0)),
+			},
+			r:       10,
+			v:       *vector.New(1, 2, 3),
+			o:       Distance,
+			success: feasibility.Feasible,
+			want:    *vector.New(-1, 1, 1),
+		},
+		{
+			name: "Partial",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 1), *vnd.New(0, 0, 1)),
+				*hyperplane.New(*vnd.New(0, 0, -1), *vnd.New(0, 0, -1)),
+			},
+			r:       10,
+			v:       *vector.New(0, 0, 0),
+			o:       Distance,
+			success: feasibility.Partial,
+			want:    *vector.New(0, 0, 1),
+		},
+		{
+			name: "Partial/Bounds",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 2), *vnd.New(0, 0, 1)),
+			},
+			r:       1,
+			v:       *vector.New(0, 0, 0),
+			o:       Distance,
+			success: feasibility.Partial,
+			want:    *vector.New(0, 0, 0),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got, f := Solve(c.cs, c.r, c.v, c.o); f != c.success || !vector.Within(got, c.want) {
+				t.Errorf("Solve() = %v, %v, want = %v, %v", got, f, c.want, c.success)
+			}
+		})
+	}
+}
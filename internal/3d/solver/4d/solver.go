@@ -0,0 +1,104 @@
+// Package solver solves an infeasible 3D linear programming problem by adding
+// a slack variable, i.e. projecting the problem into 4D ambient space.
+//
+// As in the 2D case (see internal/solver/3d), we find the solution which
+// minimizes the maximum penetration distance into the infeasible region of
+// any constraint. For each constraint which is violated by more than the
+// current penetration distance, we project all previous constraints onto the
+// violated constraint plane, and solve the resultant 3D problem, using the
+// direction of the violated constraint normal as the optimization target.
+//
+// This is a port of linearProgram4 in the official RVO2-3D implementation.
+package solver
+
+import (
+	"github.com/downflux/go-geometry/3d/vector"
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-orca/internal/solver/feasibility"
+
+	vnd "github.com/downflux/go-geometry/nd/vector"
+	s3d "github.com/downflux/go-orca/internal/3d/solver/3d"
+)
+
+const (
+	// tolerance is the threshold under which we consider vectors to be
+	// degenerate, and matches the RVO_EPSILON value in RVO2-3D.
+	tolerance = 1e-5
+)
+
+// Solve calculates a solution to an infeasible 3D linear programming problem,
+// bounded by a sphere of radius r, given an initial solution v which lies
+// within the sphere.
+func Solve(cs []hyperplane.HP, r float64, v vector.V) (vector.V, feasibility.F) {
+	if vector.SquaredMagnitude(v) > r*r*(1+tolerance) {
+		return nil, feasibility.Infeasible
+	}
+
+	// dist is the current penetration distance into the infeasible region
+	// of some constraint plane from the solution.
+	dist := 0.
+
+	for i, c := range cs {
+		if distance(c, v) <= dist {
+			continue
+		}
+
+		u, f := s3d.Solve(project(cs[:i], c), r, vector.V(c.N()), s3d.Direction)
+
+		// In the case the projected problem is infeasible due to a
+		// rounding error, we ignore the result and continue.
+		if f == feasibility.Feasible {
+			v = u
+		}
+		dist = distance(c, v)
+	}
+	return v, feasibility.Feasible
+}
+
+// project returns the set of planes which bisect each of the input constraints
+// and the incremental constraint c.
+func project(cs []hyperplane.HP, c hyperplane.HP) []hyperplane.HP {
+	n := vector.V(c.N())
+	p := vector.V(c.P())
+
+	pcs := make([]hyperplane.HP, 0, len(cs))
+	for _, d := range cs {
+		m := vector.V(d.N())
+		x := vector.Cross(m, n)
+
+		var q vector.V
+		if vector.SquaredMagnitude(x) <= tolerance {
+			// The constraints are parallel and point in the same
+			// direction; the new constraint c already dominates d.
+			if vector.Dot(n, m) > 0 {
+				continue
+			}
+			// The constraints are anti-parallel, and the bisecting
+			// plane lies halfway between the two constraints.
+			q = vector.Scale(0.5, vector.Add(p, vector.V(d.P())))
+		} else {
+			// The bisecting plane passes through the line of
+			// intersection between the two constraints.
+			l := vector.Cross(x, n)
+			q = vector.Add(
+				p,
+				vector.Scale(
+					vector.Dot(vector.Sub(vector.V(d.P()), p), m)/vector.Dot(l, m),
+					l,
+				),
+			)
+		}
+
+		pcs = append(pcs, *hyperplane.New(
+			vnd.V(q),
+			vnd.V(vector.Unit(vector.Sub(m, n))),
+		))
+	}
+	return pcs
+}
+
+// distance returns the penetration distance of the input vector into the
+// infeasible region of the constraint.
+func distance(c hyperplane.HP, v vector.V) float64 {
+	return vector.Dot(vector.V(c.N()), vector.Sub(vector.V(c.P()), v))
+}
@@ -0,0 +1,59 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/3d/vector"
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-orca/internal/solver/feasibility"
+
+	vnd "github.com/downflux/go-geometry/nd/vector"
+	s3d "github.com/downflux/go-orca/internal/3d/solver/3d"
+)
+
+func TestSolve(t *testing.T) {
+	type config struct {
+		name string
+		cs   []hyperplane.HP
+		r    float64
+		want vector.V
+	}
+
+	testConfigs := []config{
+		// The constraints z >= 1 and z <= -1 are disjoint; the optimal
+		// solution minimizes the penetration distance into both, i.e.
+		// lies on the plane z = 0.
+		{
+			name: "AntiParallel",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 1), *vnd.New(0, 0, 1)),
+				*hyperplane.New(*vnd.New(0, 0, -1), *vnd.New(0, 0, -1)),
+			},
+			r:    10,
+			want: *vector.New(0, 0, 0),
+		},
+		// The constraint z >= 2 lies outside the bounding sphere; the
+		// solution should move as far into the feasible region as
+		// possible.
+		{
+			name: "Bounds",
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vnd.New(0, 0, 2), *vnd.New(0, 0, 1)),
+			},
+			r:    1,
+			want: *vector.New(0, 0, 1),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			v, f := s3d.Solve(c.cs, c.r, *vector.New(0, 0, 0), s3d.Distance)
+			if f != feasibility.Partial {
+				t.Fatalf("Solve() = _, %v, want = _, %v", f, feasibility.Partial)
+			}
+			if got, f := Solve(c.cs, c.r, v); f != feasibility.Feasible || !vector.Within(got, c.want) {
+				t.Errorf("Solve() = %v, %v, want = %v, %v", got, f, c.want, feasibility.Feasible)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Package solver finds the optimal velocity for a 3D agent which satisfies a
+// set of ORCA planes.
+package solver
+
+import (
+	"github.com/downflux/go-geometry/3d/vector"
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-orca/internal/solver/feasibility"
+
+	s3d "github.com/downflux/go-orca/internal/3d/solver/3d"
+	s4d "github.com/downflux/go-orca/internal/3d/solver/4d"
+)
+
+// Solve attempts to find a vector which satisfies all constraints and minimizes
+// the distance to the input preferred vector v, with maximum length of v set to
+// r. If the constraints are infeasible, Solve instead returns the vector which
+// minimizes the maximum violation of any constraint.
+func Solve(cs []hyperplane.HP, v vector.V, r float64) vector.V {
+	u, f := s3d.Solve(cs, r, v, s3d.Distance)
+	if f == feasibility.Partial {
+		u, f = s4d.Solve(cs, r, u)
+	}
+	if f != feasibility.Feasible {
+		panic("cannot solve linear programming problem for the given set of ORCA planes")
+	}
+
+	return u
+}
@@ -0,0 +1,102 @@
+// Package agent defines a 3D velocity obstacle object which is constructed from
+// two spherical agents.
+//
+// The truncated VO in 3D is a cone with a spherical cap, i.e. the analog of
+// the 2D truncated cone, rotated about the relative position axis. The ORCA
+// constraint is then a plane (rather than a line) which is tangent to the VO
+// at the point closest to the relative velocity between the agents.
+//
+// This is a port of Agent::computeNewVelocity in the official RVO2-3D
+// implementation.
+package agent
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/3d/vector"
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-orca/agent/3d"
+
+	vnd "github.com/downflux/go-geometry/nd/vector"
+)
+
+const (
+	minTau = 1e-3
+)
+
+type VO struct {
+	obstacle agent.A
+}
+
+func New(obstacle agent.A) *VO {
+	return &VO{
+		obstacle: obstacle,
+	}
+}
+
+// ORCA returns the half-space of permissable velocities for the input agent.
+// As with the 2D case, each agent takes half of the responsibility of avoiding
+// the other.
+func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP {
+	if tau < minTau {
+		panic("cannot construct ORCA constraint: invalid minimum lookahead timestep")
+	}
+
+	u, n := preprocess(
+		vector.Sub(vo.obstacle.P(), a.P()),
+		vector.Sub(a.V(), vo.obstacle.V()),
+		a.R()+vo.obstacle.R(),
+		tau,
+	)
+	return *hyperplane.New(
+		vnd.V(vector.Add(a.V(), vector.Scale(0.5, u))),
+		vnd.V(n),
+	)
+}
+
+// preprocess calculates the vector u between the relative velocity v and the
+// closest point on the boundary of the truncated VO, along with the outward
+// normal n of the VO at that point.
+//
+// Here p is the relative position between the agents, and r is the combined
+// radius.
+func preprocess(p vector.V, v vector.V, r float64, tau float64) (vector.V, vector.V) {
+	d := vector.SquaredMagnitude(p)
+
+	// The agents are already overlapping; we push the agents apart as
+	// fast as possible by considering the VO over a single minimal
+	// timestep.
+	if d <= r*r {
+		w := vector.Sub(v, vector.Scale(1/minTau, p))
+		l := vector.Magnitude(w)
+		n := vector.Scale(1/l, w)
+		return vector.Scale(r/minTau-l, n), n
+	}
+
+	// w is the relative velocity centered on the truncation sphere.
+	w := vector.Sub(v, vector.Scale(1/tau, p))
+	l := vector.SquaredMagnitude(w)
+	dot := vector.Dot(w, p)
+
+	// Project v onto the truncation sphere.
+	if dot < 0 && dot*dot > r*r*l {
+		l := math.Sqrt(l)
+		n := vector.Scale(1/l, w)
+		return vector.Scale(r/tau-l, n), n
+	}
+
+	// Project v onto the cone. Here we are looking for the scalar t such
+	// that the point tp lies on the cone axis, and v - tp is perpendicular
+	// to the surface of the cone, i.e.
+	//
+	//   a t² - 2 b t + c = 0
+	a := d
+	b := vector.Dot(p, v)
+	c := vector.SquaredMagnitude(v) - vector.SquaredMagnitude(vector.Cross(p, v))/(d-r*r)
+	t := (b + math.Sqrt(math.Max(0, b*b-a*c))) / a
+
+	w = vector.Sub(v, vector.Scale(t, p))
+	m := vector.Magnitude(w)
+	n := vector.Scale(1/m, w)
+	return vector.Scale(r*t-m, n), n
+}
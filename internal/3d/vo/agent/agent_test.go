@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/3d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+
+	v2d "github.com/downflux/go-geometry/2d/vector"
+	vnd "github.com/downflux/go-geometry/nd/vector"
+	agent3d "github.com/downflux/go-orca/internal/3d/agent"
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+	voagent "github.com/downflux/go-orca/internal/vo/agent"
+)
+
+// rn returns a random int between [-100, 100).
+func rn() float64 { return rand.Float64()*200 - 100 }
+
+// TestConformance checks that the 3D VO for agents which all lie on the
+// XY-plane matches the 2D VO.
+//
+// N.B.: The 2D VO projects v onto a truncation circle with the unscaled
+// combined radius, whereas the 3D VO (per RVO2-3D) projects onto the scaled
+// truncation sphere. The two constructions coincide when 𝜏 = 1.
+func TestConformance(t *testing.T) {
+	const n = 1000
+
+	type config struct {
+		name     string
+		agent    agentimpl.A
+		obstacle agentimpl.A
+	}
+
+	testConfigs := []config{
+		{
+			name:     "SimpleCase",
+			agent:    *agentimpl.New(agentimpl.O{P: *v2d.New(0, 0), V: *v2d.New(0, 0), R: 1}),
+			obstacle: *agentimpl.New(agentimpl.O{P: *v2d.New(0, 5), V: *v2d.New(1, -1), R: 2}),
+		},
+		{
+			name:     "Collision",
+			agent:    *agentimpl.New(agentimpl.O{P: *v2d.New(0, 0), V: *v2d.New(0, 0), R: 1}),
+			obstacle: *agentimpl.New(agentimpl.O{P: *v2d.New(0, 2), V: *v2d.New(1, -1), R: 2}),
+		},
+	}
+	for i := 0; i < n; i++ {
+		testConfigs = append(testConfigs, config{
+			name: fmt.Sprintf("Random-%v", i),
+			agent: *agentimpl.New(agentimpl.O{
+				P: *v2d.New(rn(), rn()),
+				V: *v2d.New(rn(), rn()),
+				R: math.Abs(rn()),
+			}),
+			obstacle: *agentimpl.New(agentimpl.O{
+				P: *v2d.New(rn(), rn()),
+				V: *v2d.New(rn(), rn()),
+				R: math.Abs(rn()),
+			}),
+		})
+	}
+
+	// lift embeds the input 2D agent into the XY-plane.
+	lift := func(a agentimpl.A) agent3d.A {
+		return *agent3d.New(agent3d.O{
+			P: *vector.New(a.P().X(), a.P().Y(), 0),
+			V: *vector.New(a.V().X(), a.V().Y(), 0),
+			R: a.R(),
+		})
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			hp := voagent.New(c.obstacle, opt.O{
+				Weight: opt.WeightEqual,
+				VOpt:   opt.VOptV,
+			}).ORCA(c.agent, 1)
+			want := *hyperplane.New(
+				*vnd.New(hp.P().X(), hp.P().Y(), 0),
+				*vnd.New(hp.N().X(), hp.N().Y(), 0),
+			)
+
+			got := New(lift(c.obstacle)).ORCA(lift(c.agent), 1)
+			if !hyperplane.WithinEpsilon(got, want, epsilon.Absolute(1e-5)) {
+				t.Errorf("ORCA() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+func TestORCA(t *testing.T) {
+	type config struct {
+		name     string
+		agent    agent3d.A
+		obstacle agent3d.A
+		tau      float64
+		want     hyperplane.HP
+	}
+
+	testConfigs := []config{
+		// The agent is moving directly towards the obstacle along the
+		// Z-axis; the relative velocity is projected onto the
+		// truncation sphere, which is centered at (0, 0, 5) with
+		// radius 2.
+		{
+			name: "Sphere",
+			agent: *agent3d.New(agent3d.O{
+				P: *vector.New(0, 0, 0),
+				V: *vector.New(0, 0, 4),
+				R: 1,
+			}),
+			obstacle: *agent3d.New(agent3d.O{
+				P: *vector.New(0, 0, 5),
+				V: *vector.New(0, 0, 0),
+				R: 1,
+			}),
+			tau: 1,
+			want: *hyperplane.New(
+				*vnd.New(0, 0, 3.5),
+				*vnd.New(0, 0, -1),
+			),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := New(c.obstacle).ORCA(c.agent, c.tau); !hyperplane.Within(got, c.want) {
+				t.Errorf("ORCA() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
@@ -8,8 +8,15 @@ import (
 type C struct {
 	c       constraint.C
 	mutable bool
+
+	// tier is the priority tier of a mutable constraint. When the system
+	// of constraints is infeasible, constraints in lower tiers are relaxed
+	// before any constraint in a higher tier.
+	tier int
 }
 
+// New constructs a new constraint. Mutable constraints constructed via New are
+// in the default priority tier 0.
 func New(c constraint.C, mutable bool) *C {
 	return &C{
 		c:       c,
@@ -17,6 +24,16 @@ func New(c constraint.C, mutable bool) *C {
 	}
 }
 
+// NewTier constructs a new mutable constraint in the input priority tier.
+func NewTier(c constraint.C, tier int) *C {
+	return &C{
+		c:       c,
+		mutable: true,
+		tier:    tier,
+	}
+}
+
 func (c C) C() constraint.C    { return c.c }
 func (c C) In(v vector.V) bool { return c.C().In(v) }
 func (c C) Mutable() bool      { return c.mutable }
+func (c C) Tier() int          { return c.tier }
@@ -0,0 +1,284 @@
+// Package polygon defines a rounded convex polygon, i.e. the Minkowski sum of a
+// convex polygon and a disc.
+//
+// A rounded convex polygon is a convenient primitive for collision footprints
+// -- a disc is a rounded polygon with a single vertex, a capsule is a rounded
+// polygon with two vertices, and a (sharp) convex polygon is a rounded polygon
+// with a zero-radius disc. The family is closed under Minkowski sums, which
+// allows us to reduce the interaction between two footprints into the
+// interaction between a single rounded polygon and a point.
+package polygon
+
+import (
+	"math"
+	"sort"
+
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+// P is a rounded convex polygon.
+type P struct {
+	// vs is the list of vertices of the underlying convex polygon, in
+	// counter-clockwise order.
+	vs []vector.V
+
+	// r is the radius of the disc swept along the polygon.
+	r float64
+}
+
+// New constructs a rounded polygon from the convex hull of the input vertices.
+//
+// The input vertices do not need to be ordered or convex.
+func New(vs []vector.V, r float64) *P {
+	if len(vs) == 0 {
+		panic("cannot construct a polygon with no vertices")
+	}
+	if r < 0 {
+		panic("cannot construct a polygon with a negative radius")
+	}
+	return &P{
+		vs: hull(vs),
+		r:  r,
+	}
+}
+
+// V returns the vertices of the underlying convex polygon, in counter-clockwise
+// order.
+func (p P) V() []vector.V { return p.vs }
+
+// R returns the radius of the rounded corners of the polygon.
+func (p P) R() float64 { return p.r }
+
+// Scale returns a copy of the polygon, scaled about the origin by a positive
+// factor.
+func Scale(c float64, p P) P {
+	vs := make([]vector.V, 0, len(p.V()))
+	for _, v := range p.V() {
+		vs = append(vs, vector.Scale(c, v))
+	}
+	return P{vs: vs, r: c * p.R()}
+}
+
+// Translate returns a copy of the polygon, shifted by the input vector.
+func Translate(v vector.V, p P) P {
+	vs := make([]vector.V, 0, len(p.V()))
+	for _, u := range p.V() {
+		vs = append(vs, vector.Add(v, u))
+	}
+	return P{vs: vs, r: p.R()}
+}
+
+// Sum returns the Minkowski sum of two rounded polygons. The underlying
+// polygon of the sum is the convex hull of the pairwise vertex sums, and the
+// radii are additive.
+func Sum(p P, q P) P {
+	vs := make([]vector.V, 0, len(p.V())*len(q.V()))
+	for _, u := range p.V() {
+		for _, v := range q.V() {
+			vs = append(vs, vector.Add(u, v))
+		}
+	}
+	return *New(vs, p.R()+q.R())
+}
+
+// Reflect returns the polygon reflected through the origin, i.e. -P. This is
+// useful in constructing the Minkowski difference P ⊕ (-Q).
+func Reflect(p P) P {
+	vs := make([]vector.V, 0, len(p.V()))
+	for _, v := range p.V() {
+		vs = append(vs, vector.Scale(-1, v))
+	}
+	return *New(vs, p.R())
+}
+
+// Distance returns the signed distance from the input vector to the boundary
+// of the rounded polygon. The distance is negative if v lies inside the
+// polygon.
+func (p P) Distance(v vector.V) float64 {
+	b, _ := p.B(v)
+	d := vector.Magnitude(vector.Sub(v, b))
+	if p.In(v) {
+		return -d
+	}
+	return d
+}
+
+// In checks if the input vector lies within the (closed) rounded polygon.
+func (p P) In(v vector.V) bool {
+	if p.core(v) {
+		return true
+	}
+	q, _ := p.closest(v)
+	return vector.SquaredMagnitude(vector.Sub(v, q)) <= p.R()*p.R()
+}
+
+// B returns the point on the boundary of the rounded polygon closest to the
+// input vector, along with the outward unit normal of the boundary at that
+// point.
+func (p P) B(v vector.V) (vector.V, vector.V) {
+	if p.core(v) {
+		// v lies strictly inside the underlying polygon -- the closest
+		// boundary point lies on the offset edge with the smallest
+		// perpendicular distance to v.
+		d := math.Inf(0)
+		var n, f vector.V
+		for i := range p.vs {
+			a, b := p.edge(i)
+			e := vector.Unit(vector.Sub(b, a))
+			// m is the outward normal of a counter-clockwise edge.
+			m := *vector.New(e.Y(), -e.X())
+			if h := -vector.Dot(m, vector.Sub(v, a)); h < d {
+				d = h
+				n = m
+				f = vector.Add(v, vector.Scale(h, m))
+			}
+		}
+		return vector.Add(f, vector.Scale(p.R(), n)), n
+	}
+
+	q, n := p.closest(v)
+	if w := vector.Sub(v, q); vector.SquaredMagnitude(w) > 0 {
+		n = vector.Unit(w)
+	}
+	return vector.Add(q, vector.Scale(p.R(), n)), n
+}
+
+// T returns the points of tangency of the left and right tangent lines drawn
+// from the origin to the rounded polygon. Here, "left" refers to the tangent
+// line which is rotated anti-clockwise relative to the polygon, as viewed from
+// the origin.
+//
+// T returns false if the origin lies within the polygon, as the tangent lines
+// are not defined in this case.
+func (p P) T() (vector.V, vector.V, bool) {
+	if p.In(*vector.New(0, 0)) {
+		return vector.V{}, vector.V{}, false
+	}
+
+	var l, r vector.V
+	for i, q := range p.vs {
+		// For a disc centered at q with radius r, the tangent legs are
+		// rotated by ±𝜙 from q, where sin(𝜙) = r / ||q||; the tangent
+		// points lie at a distance of ||q|| cos(𝜙) from the origin.
+		m := vector.Magnitude(q)
+		s := p.R() / m
+		c := math.Sqrt(1 - s*s)
+
+		tl := vector.Scale(c, *vector.New(q.X()*c-q.Y()*s, q.X()*s+q.Y()*c))
+		tr := vector.Scale(c, *vector.New(q.X()*c+q.Y()*s, -q.X()*s+q.Y()*c))
+
+		if i == 0 || vector.Determinant(l, tl) > 0 {
+			l = tl
+		}
+		if i == 0 || vector.Determinant(r, tr) < 0 {
+			r = tr
+		}
+	}
+	return l, r, true
+}
+
+// core checks if the input vector lies strictly within the underlying
+// (unrounded) polygon.
+func (p P) core(v vector.V) bool {
+	if len(p.vs) < 3 {
+		return false
+	}
+	for i := range p.vs {
+		a, b := p.edge(i)
+		if vector.Determinant(vector.Sub(b, a), vector.Sub(v, a)) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// closest returns the point on the underlying polygon closest to the input
+// vector, along with a fallback outward normal to use in the case the input
+// vector lies directly on the polygon boundary.
+//
+// N.B.: closest assumes v does not lie strictly within the polygon.
+func (p P) closest(v vector.V) (vector.V, vector.V) {
+	if len(p.vs) == 1 {
+		return p.vs[0], *vector.New(1, 0)
+	}
+
+	d := math.Inf(0)
+	var q, n vector.V
+	for i := range p.vs {
+		// A two-vertex polygon is a single segment -- we do not want to
+		// double count the (reversed) closing edge.
+		if len(p.vs) == 2 && i == 1 {
+			break
+		}
+		a, b := p.edge(i)
+		e := vector.Sub(b, a)
+		t := math.Max(0, math.Min(1, vector.Dot(vector.Sub(v, a), e)/vector.SquaredMagnitude(e)))
+		f := vector.Add(a, vector.Scale(t, e))
+		if m := vector.SquaredMagnitude(vector.Sub(v, f)); m < d {
+			d = m
+			q = f
+			n = vector.Unit(*vector.New(e.Y(), -e.X()))
+		}
+	}
+	return q, n
+}
+
+// edge returns the i-th counter-clockwise edge of the polygon.
+func (p P) edge(i int) (vector.V, vector.V) {
+	return p.vs[i], p.vs[(i+1)%len(p.vs)]
+}
+
+// hull returns the convex hull of the input points in counter-clockwise order,
+// via Andrew's monotone chain algorithm. Collinear and duplicate points are
+// removed.
+func hull(vs []vector.V) []vector.V {
+	ps := make([]vector.V, 0, len(vs))
+	for _, v := range vs {
+		ps = append(ps, v)
+	}
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].X() != ps[j].X() {
+			return ps[i].X() < ps[j].X()
+		}
+		return ps[i].Y() < ps[j].Y()
+	})
+
+	// Remove duplicate points.
+	us := ps[:1]
+	for _, p := range ps[1:] {
+		if !vector.Within(p, us[len(us)-1]) {
+			us = append(us, p)
+		}
+	}
+	if len(us) < 3 {
+		return us
+	}
+
+	cross := func(o, a, b vector.V) float64 {
+		return vector.Determinant(vector.Sub(a, o), vector.Sub(b, o))
+	}
+
+	h := make([]vector.V, 0, 2*len(us))
+	for _, p := range us {
+		for len(h) >= 2 && cross(h[len(h)-2], h[len(h)-1], p) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, p)
+	}
+	for i, t := len(us)-2, len(h)+1; i >= 0; i-- {
+		p := us[i]
+		for len(h) >= t && cross(h[len(h)-2], h[len(h)-1], p) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, p)
+	}
+
+	// The last point is a duplicate of the first point.
+	h = h[:len(h)-1]
+
+	// All points are collinear -- the hull is a line segment.
+	if len(h) < 2 {
+		return []vector.V{us[0], us[len(us)-1]}
+	}
+	return h
+}
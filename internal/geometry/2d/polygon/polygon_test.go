@@ -0,0 +1,246 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNew(t *testing.T) {
+	type config struct {
+		name string
+		vs   []vector.V
+		want []vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name: "Point",
+			vs:   []vector.V{*vector.New(1, 2)},
+			want: []vector.V{*vector.New(1, 2)},
+		},
+		{
+			name: "Point/Duplicate",
+			vs:   []vector.V{*vector.New(1, 2), *vector.New(1, 2)},
+			want: []vector.V{*vector.New(1, 2)},
+		},
+		{
+			name: "Segment",
+			vs:   []vector.V{*vector.New(1, 0), *vector.New(-1, 0)},
+			want: []vector.V{*vector.New(-1, 0), *vector.New(1, 0)},
+		},
+		{
+			name: "Segment/Collinear",
+			vs: []vector.V{
+				*vector.New(1, 0),
+				*vector.New(0, 0),
+				*vector.New(-1, 0),
+			},
+			want: []vector.V{*vector.New(-1, 0), *vector.New(1, 0)},
+		},
+		{
+			name: "Square/Interior",
+			vs: []vector.V{
+				*vector.New(1, 1),
+				*vector.New(-1, -1),
+				*vector.New(0, 0),
+				*vector.New(-1, 1),
+				*vector.New(1, -1),
+			},
+			want: []vector.V{
+				*vector.New(-1, -1),
+				*vector.New(1, -1),
+				*vector.New(1, 1),
+				*vector.New(-1, 1),
+			},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if diff := cmp.Diff(c.want, New(c.vs, 0).V()); diff != "" {
+				t.Errorf("V() mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestB(t *testing.T) {
+	square := *New(
+		[]vector.V{
+			*vector.New(-1, -1),
+			*vector.New(1, -1),
+			*vector.New(1, 1),
+			*vector.New(-1, 1),
+		},
+		1,
+	)
+
+	type config struct {
+		name string
+		p    P
+		v    vector.V
+		b    vector.V
+		n    vector.V
+		in   bool
+	}
+
+	testConfigs := []config{
+		{
+			name: "Circle/Outside",
+			p:    *New([]vector.V{*vector.New(0, 0)}, 1),
+			v:    *vector.New(0, 2),
+			b:    *vector.New(0, 1),
+			n:    *vector.New(0, 1),
+			in:   false,
+		},
+		{
+			name: "Circle/Inside",
+			p:    *New([]vector.V{*vector.New(0, 0)}, 1),
+			v:    *vector.New(0.5, 0),
+			b:    *vector.New(1, 0),
+			n:    *vector.New(1, 0),
+			in:   true,
+		},
+		{
+			name: "Capsule/Side",
+			p:    *New([]vector.V{*vector.New(-1, 0), *vector.New(1, 0)}, 1),
+			v:    *vector.New(0, -3),
+			b:    *vector.New(0, -1),
+			n:    *vector.New(0, -1),
+			in:   false,
+		},
+		{
+			name: "Capsule/End",
+			p:    *New([]vector.V{*vector.New(-1, 0), *vector.New(1, 0)}, 1),
+			v:    *vector.New(3, 0),
+			b:    *vector.New(2, 0),
+			n:    *vector.New(1, 0),
+			in:   false,
+		},
+		{
+			name: "Square/Edge",
+			p:    square,
+			v:    *vector.New(3, 0),
+			b:    *vector.New(2, 0),
+			n:    *vector.New(1, 0),
+			in:   false,
+		},
+		{
+			name: "Square/Corner",
+			p:    square,
+			v:    *vector.New(3, 3),
+			b:    *vector.New(1+math.Sqrt2/2, 1+math.Sqrt2/2),
+			n:    *vector.New(math.Sqrt2/2, math.Sqrt2/2),
+			in:   false,
+		},
+		{
+			name: "Square/Core",
+			p:    square,
+			v:    *vector.New(0, 0.5),
+			b:    *vector.New(0, 2),
+			n:    *vector.New(0, 1),
+			in:   true,
+		},
+		{
+			name: "Square/Rounded",
+			p:    square,
+			v:    *vector.New(0, 1.5),
+			b:    *vector.New(0, 2),
+			n:    *vector.New(0, 1),
+			in:   true,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			b, n := c.p.B(c.v)
+			if !vector.Within(b, c.b) {
+				t.Errorf("B() = %v, _, want = %v, _", b, c.b)
+			}
+			if !vector.Within(n, c.n) {
+				t.Errorf("B() = _, %v, want = _, %v", n, c.n)
+			}
+			if got := c.p.In(c.v); got != c.in {
+				t.Errorf("In() = %v, want = %v", got, c.in)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	type config struct {
+		name    string
+		p       P
+		success bool
+		l       vector.V
+		r       vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name:    "Circle/345",
+			p:       *New([]vector.V{*vector.New(0, 5)}, 3),
+			success: true,
+			l:       *vector.New(-2.4, 3.2),
+			r:       *vector.New(2.4, 3.2),
+		},
+		{
+			name: "Segment",
+			p: *New(
+				[]vector.V{
+					*vector.New(-1, 1),
+					*vector.New(1, 1),
+				},
+				0,
+			),
+			success: true,
+			l:       *vector.New(-1, 1),
+			r:       *vector.New(1, 1),
+		},
+		{
+			name:    "Collision",
+			p:       *New([]vector.V{*vector.New(0, 1)}, 3),
+			success: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			l, r, ok := c.p.T()
+			if ok != c.success {
+				t.Errorf("T() = _, _, %v, want = _, _, %v", ok, c.success)
+			}
+			if !ok {
+				return
+			}
+			if !vector.Within(l, c.l) {
+				t.Errorf("T() = %v, _, _, want = %v, _, _", l, c.l)
+			}
+			if !vector.Within(r, c.r) {
+				t.Errorf("T() = _, %v, _, want = _, %v, _", r, c.r)
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	p := *New([]vector.V{*vector.New(-1, 0), *vector.New(1, 0)}, 1)
+	q := *New([]vector.V{*vector.New(0, -1), *vector.New(0, 1)}, 2)
+
+	got := Sum(p, q)
+	want := *New(
+		[]vector.V{
+			*vector.New(-1, -1),
+			*vector.New(1, -1),
+			*vector.New(1, 1),
+			*vector.New(-1, 1),
+		},
+		3,
+	)
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(P{})); diff != "" {
+		t.Errorf("Sum() mismatch (-want +got):\n%v", diff)
+	}
+}
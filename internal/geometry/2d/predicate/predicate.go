@@ -0,0 +1,161 @@
+// Package predicate implements robust geometric predicates in 2D ambient space.
+//
+// The predicates classify the position of a point relative to a line or circle,
+// and return the sign of a polynomial in the input coordinates, e.g. the
+// orientation of three points is the sign of the determinant
+//
+//	| bx - ax   cx - ax |
+//	| by - ay   cy - ay |
+//
+// Evaluating such a polynomial directly in floating point arithmetic may return
+// the wrong sign when the input is nearly degenerate, e.g. when the three
+// points are nearly collinear. Different predicates evaluated over the same
+// input may then contradict one another, e.g. a point may lie both strictly
+// inside a circle and strictly outside every edge of a polygon inscribed in the
+// circle.
+//
+// Per Shewchuk (1997), we first evaluate the polynomial in floating point
+// arithmetic along with a bound on its rounding error, and only fall back to
+// exact arithmetic if the sign is not certain, i.e. if the magnitude of the
+// floating point result is smaller than the error bound. As the fallback is
+// rarely taken, the predicates are only slightly more expensive than the naive
+// floating point implementation.
+//
+// All predicates return +1, 0, or -1. The returned sign is exact with respect
+// to the (floating point) input; in particular, a zero result means the input
+// is exactly degenerate.
+package predicate
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+var (
+	// e is the machine epsilon for float64 arithmetic, i.e. half the
+	// distance between 1 and the next representable value.
+	e = math.Ldexp(1, -53)
+
+	// The error bounds below are (slightly relaxed) versions of the
+	// bounds derived in Shewchuk (1997). Each bound is a multiple of the
+	// sum of the absolute values of the terms of the polynomial.
+	ccwerrbound   = (3 + 16*e) * e
+	circerrbound  = (8 + 64*e) * e
+	striperrbound = (16 + 256*e) * e
+
+	// tiny is the magnitude below which the products in the floating
+	// point evaluation may have underflowed, and the error bounds no
+	// longer hold.
+	tiny = math.Ldexp(1, -900)
+)
+
+// Orientation returns the orientation of the triangle a, b, c, i.e. +1 if the
+// points are in counter-clockwise order, -1 if they are in clockwise order, and
+// 0 if they are collinear.
+func Orientation(a vector.V, b vector.V, c vector.V) int {
+	l := (b.X() - a.X()) * (c.Y() - a.Y())
+	r := (b.Y() - a.Y()) * (c.X() - a.X())
+	if s, ok := filter(l-r, math.Abs(l)+math.Abs(r), ccwerrbound); ok {
+		return s
+	}
+
+	return sub(
+		mul(sub(rat(b.X()), rat(a.X())), sub(rat(c.Y()), rat(a.Y()))),
+		mul(sub(rat(b.Y()), rat(a.Y())), sub(rat(c.X()), rat(a.X()))),
+	).Sign()
+}
+
+// Side returns the sign of ⟨v - p, n⟩, i.e. +1 if v lies strictly on the side
+// of the line through p normal to n towards which n points, -1 if v lies
+// strictly on the opposite side, and 0 if v lies on the line.
+//
+// For a half-plane with the point-normal form (p, n), Side(p, n, v) ≥ 0 checks
+// if v lies within the half-plane.
+func Side(p vector.V, n vector.V, v vector.V) int {
+	l := (v.X() - p.X()) * n.X()
+	r := (v.Y() - p.Y()) * n.Y()
+	if s, ok := filter(l+r, math.Abs(l)+math.Abs(r), ccwerrbound); ok {
+		return s
+	}
+
+	return add(
+		mul(sub(rat(v.X()), rat(p.X())), rat(n.X())),
+		mul(sub(rat(v.Y()), rat(p.Y())), rat(n.Y())),
+	).Sign()
+}
+
+// InCircle returns +1 if v lies strictly within the circle of radius r
+// centered at c, -1 if v lies strictly outside the circle, and 0 if v lies on
+// the circle, i.e. the sign of r² - ‖v - c‖².
+func InCircle(c vector.V, r float64, v vector.V) int {
+	x, y := v.X()-c.X(), v.Y()-c.Y()
+	rr, xx, yy := r*r, x*x, y*y
+	if s, ok := filter(rr-xx-yy, rr+xx+yy, circerrbound); ok {
+		return s
+	}
+
+	dx := sub(rat(v.X()), rat(c.X()))
+	dy := sub(rat(v.Y()), rat(c.Y()))
+	return sub(
+		mul(rat(r), rat(r)),
+		add(mul(dx, dx), mul(dy, dy)),
+	).Sign()
+}
+
+// InStrip returns +1 if v lies strictly within distance r of the line through p
+// with direction d, -1 if v lies strictly further away, and 0 if v lies
+// exactly distance r away from the line, i.e. the sign of
+//
+//	r²‖d‖² - (d × (v - p))²
+//
+// where × denotes the 2D cross product (i.e. the determinant).
+func InStrip(p vector.V, d vector.V, r float64, v vector.V) int {
+	x, y := v.X()-p.X(), v.Y()-p.Y()
+	dd := d.X()*d.X() + d.Y()*d.Y()
+	l, m := d.X()*y, d.Y()*x
+	k := l - m
+	rr := r * r * dd
+	if s, ok := filter(rr-k*k, rr+(math.Abs(l)+math.Abs(m))*(math.Abs(l)+math.Abs(m)), striperrbound); ok {
+		return s
+	}
+
+	dx, dy := rat(d.X()), rat(d.Y())
+	c := sub(
+		mul(dx, sub(rat(v.Y()), rat(p.Y()))),
+		mul(dy, sub(rat(v.X()), rat(p.X()))),
+	)
+	return sub(
+		mul(mul(rat(r), rat(r)), add(mul(dx, dx), mul(dy, dy))),
+		mul(c, c),
+	).Sign()
+}
+
+// filter returns the sign of the floating point value x, given the sum of the
+// absolute values of the terms of x, and the relative error bound of the
+// evaluation of x. filter returns false if the sign of x is uncertain.
+func filter(x float64, sum float64, bound float64) (int, bool) {
+	if math.IsNaN(x) || math.IsInf(sum, 0) || sum < tiny {
+		return 0, false
+	}
+	if b := bound * sum; x > b {
+		return 1, true
+	} else if x < -b {
+		return -1, true
+	}
+	return 0, false
+}
+
+// rat returns the exact rational value of the input float. Non-finite inputs
+// are mapped to zero; the predicates make no guarantees for such inputs.
+func rat(x float64) *big.Rat {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFloat64(x)
+}
+
+func add(a *big.Rat, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) }
+func sub(a *big.Rat, b *big.Rat) *big.Rat { return new(big.Rat).Sub(a, b) }
+func mul(a *big.Rat, b *big.Rat) *big.Rat { return new(big.Rat).Mul(a, b) }
@@ -0,0 +1,180 @@
+package predicate
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+func TestOrientation(t *testing.T) {
+	type config struct {
+		name string
+		a    vector.V
+		b    vector.V
+		c    vector.V
+		want int
+	}
+
+	testConfigs := []config{
+		{
+			name: "CCW",
+			a:    *vector.New(0, 0),
+			b:    *vector.New(1, 0),
+			c:    *vector.New(0, 1),
+			want: 1,
+		},
+		{
+			name: "CW",
+			a:    *vector.New(0, 0),
+			b:    *vector.New(0, 1),
+			c:    *vector.New(1, 0),
+			want: -1,
+		},
+		{
+			name: "Collinear",
+			a:    *vector.New(0.5, 0.5),
+			b:    *vector.New(12, 12),
+			c:    *vector.New(24, 24),
+			want: 0,
+		},
+		// The naive floating point evaluation of the determinant
+		// returns 0 here, as in Kettner et al. (2008).
+		{
+			name: "NearlyCollinear",
+			a:    *vector.New(0.5, math.Nextafter(0.5, 1)),
+			b:    *vector.New(12, 12),
+			c:    *vector.New(24, 24),
+			want: 1,
+		},
+		{
+			name: "NearlyCollinear/CW",
+			a:    *vector.New(0.5, math.Nextafter(0.5, 0)),
+			b:    *vector.New(12, 12),
+			c:    *vector.New(24, 24),
+			want: -1,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Orientation(c.a, c.b, c.c); got != c.want {
+				t.Errorf("Orientation() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestConsistency checks that the predicates return consistent results for
+// nearly degenerate inputs, i.e. permuting the input points of the orientation
+// predicate only changes the sign of the result as expected.
+func TestConsistency(t *testing.T) {
+	const n = 1000
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		// Generate three points which lie (nearly) on a line.
+		a := *vector.New(r.Float64(), r.Float64())
+		d := *vector.New(r.Float64(), r.Float64())
+		b := vector.Add(a, vector.Scale(r.Float64()*100, d))
+		c := vector.Add(a, vector.Scale(r.Float64()*100, d))
+
+		t.Run(fmt.Sprintf("Random-%v", i), func(t *testing.T) {
+			o := Orientation(a, b, c)
+			for _, got := range []int{Orientation(b, c, a), Orientation(c, a, b)} {
+				if got != o {
+					t.Errorf("Orientation() = %v, want = %v", got, o)
+				}
+			}
+			for _, got := range []int{Orientation(b, a, c), Orientation(a, c, b), Orientation(c, b, a)} {
+				if got != -o {
+					t.Errorf("Orientation() = %v, want = %v", got, -o)
+				}
+			}
+		})
+	}
+}
+
+func TestSide(t *testing.T) {
+	type config struct {
+		name string
+		p    vector.V
+		n    vector.V
+		v    vector.V
+		want int
+	}
+
+	testConfigs := []config{
+		{name: "In", p: *vector.New(0, 1), n: *vector.New(0, 1), v: *vector.New(5, 2), want: 1},
+		{name: "Out", p: *vector.New(0, 1), n: *vector.New(0, 1), v: *vector.New(5, 0), want: -1},
+		{name: "On", p: *vector.New(0, 1), n: *vector.New(0, 1), v: *vector.New(5, 1), want: 0},
+		// The naive floating point evaluation returns 0 here.
+		{name: "NearlyOn", p: *vector.New(0.1, 0.3), n: *vector.New(0.7, -0.3), v: *vector.New(0.1000000000000005, 0.30000000000000115), want: -1},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Side(c.p, c.n, c.v); got != c.want {
+				t.Errorf("Side() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	type config struct {
+		name string
+		c    vector.V
+		r    float64
+		v    vector.V
+		want int
+	}
+
+	testConfigs := []config{
+		{name: "Inside", c: *vector.New(1, 1), r: 1, v: *vector.New(1.5, 1), want: 1},
+		{name: "Outside", c: *vector.New(1, 1), r: 1, v: *vector.New(2.5, 1), want: -1},
+		{name: "On", c: *vector.New(1, 1), r: 1, v: *vector.New(2, 1), want: 0},
+		{name: "On/Diagonal", c: *vector.New(0, 0), r: 5, v: *vector.New(3, 4), want: 0},
+		{name: "NearlyOn/Outside", c: *vector.New(0, 0), r: 5, v: *vector.New(3, math.Nextafter(4, 5)), want: -1},
+		{name: "NearlyOn/Inside", c: *vector.New(0, 0), r: 5, v: *vector.New(3, math.Nextafter(4, 3)), want: 1},
+		{name: "ZeroRadius", c: *vector.New(1, 1), r: 0, v: *vector.New(1, 1), want: 0},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := InCircle(c.c, c.r, c.v); got != c.want {
+				t.Errorf("InCircle() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInStrip(t *testing.T) {
+	type config struct {
+		name string
+		p    vector.V
+		d    vector.V
+		r    float64
+		v    vector.V
+		want int
+	}
+
+	testConfigs := []config{
+		{name: "Inside", p: *vector.New(0, 1), d: *vector.New(2, 0), r: 1, v: *vector.New(5, 1.5), want: 1},
+		{name: "Outside", p: *vector.New(0, 1), d: *vector.New(2, 0), r: 1, v: *vector.New(5, 2.5), want: -1},
+		{name: "On", p: *vector.New(0, 1), d: *vector.New(2, 0), r: 1, v: *vector.New(-5, 0), want: 0},
+		{name: "Outside/Diagonal", p: *vector.New(0, 0), d: *vector.New(3, 4), r: 1, v: *vector.New(4, -3), want: -1},
+		{name: "On/Diagonal/Scaled", p: *vector.New(0, 0), d: *vector.New(3, 4), r: 5, v: *vector.New(4, -3), want: 0},
+		{name: "NearlyOn", p: *vector.New(0, 1), d: *vector.New(2, 0), r: 1, v: *vector.New(1e10, math.Nextafter(2, 3)), want: -1},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := InStrip(c.p, c.d, c.r, c.v); got != c.want {
+				t.Errorf("InStrip() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
@@ -12,13 +12,13 @@
 package segment
 
 import (
-	"fmt"
-
 	"github.com/downflux/go-geometry/2d/hypersphere"
 	"github.com/downflux/go-geometry/2d/line"
 	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/2d/vector"
 	"github.com/downflux/go-orca/internal/geometry/2d/cone"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type S struct {
@@ -27,14 +27,17 @@ type S struct {
 
 	p vector.V
 
-	// r is the thickness of the line segment.
+	// radius is the radius of the line segment.
 	radius float64
 
 	l line.L
 	r line.L
 }
 
-func New(s segment.S, p vector.V, radius float64) *S {
+// New constructs a truncated line segment VO. New returns an error if the
+// characteristic circles at the ends of the segment do not define a valid cone,
+// e.g. if p lies within radius of the segment, or if the radius is zero.
+func New(s segment.S, p vector.V, radius float64) (*S, error) {
 	rpTMin := vector.Sub(s.L().L(s.TMin()), p)
 	rpTMax := vector.Sub(s.L().L(s.TMax()), p)
 
@@ -52,17 +55,11 @@ func New(s segment.S, p vector.V, radius float64) *S {
 
 	cTMin, err := cone.New(*hypersphere.New(rpTMin, radius))
 	if err != nil {
-		panic(
-			fmt.Sprintf(
-				"could not construct line segment VO object: %v",
-				err))
+		return nil, status.Errorf(codes.OutOfRange, "could not construct line segment VO object: %v", err)
 	}
 	cTMax, err := cone.New(*hypersphere.New(rpTMax, radius))
 	if err != nil {
-		panic(
-			fmt.Sprintf(
-				"could not construct line segment VO object: %v",
-				err))
+		return nil, status.Errorf(codes.OutOfRange, "could not construct line segment VO object: %v", err)
 	}
 
 	// If the end of the left tangent leg lies past the left segment,
@@ -95,7 +92,7 @@ func New(s segment.S, p vector.V, radius float64) *S {
 
 		l: l,
 		r: r,
-	}
+	}, nil
 }
 
 // S returns the base of line segment VO. Depending on the setup, this segment
@@ -32,6 +32,15 @@ func (s shim) R() line.L {
 	)
 }
 
+// must constructs a new segment VO, and panics if the VO is invalid.
+func must(s segment.S, p vector.V, r float64) *S {
+	v, err := New(s, p, r)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 func rn() float64   { return rand.Float64()*200 - 100 }
 func rv() vector.V  { return *vector.New(rn(), rn()) }
 func rs() segment.S { return *segment.New(*line.New(rv(), rv()), rn(), rn()) }
@@ -78,7 +87,10 @@ func TestConformance(t *testing.T) {
 
 	for _, c := range testConfigs {
 		t.Run(c.name, func(t *testing.T) {
-			s := New(c.s, c.p, c.r)
+			s, err := New(c.s, c.p, c.r)
+			if err != nil {
+				t.Fatalf("New() = _, %v, want = _, nil", err)
+			}
 			r, err := mock.New(c.s, c.p, c.r)
 			if err != nil {
 				t.Errorf("New() = _, %v, want = _, nil", err)
@@ -115,7 +127,7 @@ func TestL(t *testing.T) {
 		// (1, 1).
 		{
 			name: "Normal",
-			s: *New(
+			s: *must(
 				*segment.New(
 					*line.New(
 						*vector.New(-1, 1),
@@ -132,7 +144,7 @@ func TestL(t *testing.T) {
 		},
 		{
 			name: "Normal/Mirror",
-			s: *New(
+			s: *must(
 				*segment.New(
 					*line.New(
 						*vector.New(-1, -1),
@@ -149,7 +161,7 @@ func TestL(t *testing.T) {
 		},
 		{
 			name: "Oblique/Left",
-			s: *New(
+			s: *must(
 				*segment.New(
 					*line.New(
 						*vector.New(2, 0),
@@ -166,7 +178,7 @@ func TestL(t *testing.T) {
 		},
 		{
 			name: "Oblique/Right",
-			s: *New(
+			s: *must(
 				*segment.New(
 					*line.New(
 						*vector.New(-4, 0),
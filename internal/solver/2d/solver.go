@@ -8,6 +8,7 @@ import (
 	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/2d/vector"
 	"github.com/downflux/go-orca/internal/geometry/2d/constraint"
+	"github.com/downflux/go-orca/internal/geometry/2d/predicate"
 	"github.com/downflux/go-orca/internal/solver/feasibility"
 
 	c2d "github.com/downflux/go-geometry/2d/constraint"
@@ -55,6 +56,20 @@ type region struct {
 	o           O
 	constraints []constraint.C
 	infeasible  bool
+
+	// robust indicates the region should use exact geometric predicates
+	// when checking if a vector satisfies a constraint, and when
+	// intersecting two constraints.
+	robust bool
+}
+
+// in checks if the input vector satisfies the constraint.
+func (r *region) in(c constraint.C, v vector.V) bool {
+	if r.robust && finite(v) {
+		hp := hyperplane.HP(c.C())
+		return predicate.Side(hp.P(), hp.N(), v) >= 0
+	}
+	return c.In(v)
 }
 
 func (r *region) Feasible() bool        { return !r.infeasible }
@@ -105,6 +120,14 @@ func (r *region) intersect(c constraint.C) (segment.S, bool) {
 
 	l := hyperplane.Line(hyperplane.HP(c.C()))
 	for _, d := range r.constraints {
+		if r.robust {
+			if s, ok = r.clip(s, c, d); !ok {
+				r.infeasible = true
+				return segment.S{}, r.Feasible()
+			}
+			continue
+		}
+
 		i, ok := l.Intersect(
 			hyperplane.Line(hyperplane.HP(d.C())),
 		)
@@ -180,6 +203,43 @@ func (r *region) intersect(c constraint.C) (segment.S, bool) {
 	return segment.S{}, r.Feasible()
 }
 
+// clip refines the feasible segment s of the new constraint c with the existing
+// constraint d, using exact geometric predicates. clip returns false if no
+// point on the characteristic line of c satisfies d.
+//
+// The default implementation in intersect decides which end of the segment to
+// clip by checking if a point offset from the (rounded) point of intersection
+// by D(c) satisfies d; for nearly parallel lines, the point of intersection may
+// be so far away that the offset is lost to rounding. clip instead uses the
+// exact sign of ⟨D(c), N(d)⟩, i.e. the relative orientation of the two lines,
+// and only treats the lines as parallel if they are exactly parallel.
+func (r *region) clip(s segment.S, c constraint.C, d constraint.C) (segment.S, bool) {
+	hc, hd := hyperplane.HP(c.C()), hyperplane.HP(d.C())
+	l := hyperplane.Line(hc)
+
+	o := predicate.Side(*vector.New(0, 0), hd.N(), l.D())
+	if o == 0 {
+		// The lines are parallel, and the line of c either lies
+		// entirely within or entirely outside d.
+		return s, predicate.Side(hd.P(), hd.N(), hc.P()) >= 0
+	}
+
+	// Solve ⟨l.L(t) - P(d), N(d)⟩ = 0 for t. The denominator may round to
+	// the wrong sign (or to zero) for nearly parallel lines, so we use the
+	// exact orientation instead.
+	n := vector.Dot(vector.Sub(hd.P(), l.P()), hd.N())
+	t := n / (float64(o) * math.Abs(vector.Dot(l.D(), hd.N())))
+	if math.IsNaN(t) {
+		t = 0
+	}
+	if o > 0 {
+		s = *segment.New(l, math.Max(s.TMin(), t), s.TMax())
+	} else {
+		s = *segment.New(l, s.TMin(), math.Min(s.TMax(), t))
+	}
+	return s, true
+}
+
 // Solve attempts to calculate a solution to the linear programming problem
 // which satisifes all input constraints and maximizes / minimizes the given
 // input optimization function. Solve will return infeasible if there is no such
@@ -202,6 +262,18 @@ func (r *region) intersect(c constraint.C) (segment.S, bool) {
 // optimization functions, this is the v0 defined in Algorithm 2DBoundedLP of de
 // Berg.
 func Solve(m M, cs []constraint.C, o O, v vector.V) (vector.V, feasibility.F) {
+	return solve(m, cs, o, v, false)
+}
+
+// SolveRobust is a variant of Solve which uses exact geometric predicates to
+// check if the current solution satisfies a constraint, and to intersect
+// constraints. This ensures nearly parallel constraints and solutions which lie
+// (nearly) on a constraint line are classified consistently.
+func SolveRobust(m M, cs []constraint.C, o O, v vector.V) (vector.V, feasibility.F) {
+	return solve(m, cs, o, v, true)
+}
+
+func solve(m M, cs []constraint.C, o O, v vector.V, robust bool) (vector.V, feasibility.F) {
 	if !m.In(v) {
 		return vector.V{}, feasibility.Infeasible
 	}
@@ -210,9 +282,10 @@ func Solve(m M, cs []constraint.C, o O, v vector.V) (vector.V, feasibility.F) {
 		m:           m,
 		o:           o,
 		constraints: make([]constraint.C, 0, len(cs)),
+		robust:      robust,
 	}
 	for _, c := range cs {
-		if !c.In(v) {
+		if !r.in(c, v) {
 			if u, ok := r.Solve(c); ok {
 				v = u
 			} else {
@@ -224,3 +297,14 @@ func Solve(m M, cs []constraint.C, o O, v vector.V) (vector.V, feasibility.F) {
 	}
 	return v, feasibility.Feasible
 }
+
+// finite checks if the input vector has finite coordinates. The initial
+// solution of e.g. an unbounded LP may lie at infinity.
+func finite(v vector.V) bool {
+	for _, x := range []float64{v.X(), v.Y()} {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,19 +3,24 @@ package solver
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/downflux/go-geometry/2d/hyperplane"
 	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
 	"github.com/downflux/go-geometry/nd/line"
 	"github.com/downflux/go-orca/internal/geometry/2d/constraint"
+	"github.com/downflux/go-orca/internal/solver/bounds/circular"
 	"github.com/downflux/go-orca/internal/solver/bounds/unbounded"
 	"github.com/downflux/go-orca/internal/solver/feasibility"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	c2d "github.com/downflux/go-geometry/2d/constraint"
 	l2d "github.com/downflux/go-geometry/2d/line"
+	reference "github.com/downflux/go-orca/external/snape/RVO2/solver"
 )
 
 func TestIntersect(t *testing.T) {
@@ -303,5 +308,134 @@ func TestSolve(t *testing.T) {
 				t.Errorf("Solve() = %v, %v, want = %v, %v", got, f, c.want, c.success)
 			}
 		})
+		t.Run(fmt.Sprintf("%v/Robust", c.name), func(t *testing.T) {
+			if got, f := SolveRobust(c.m, c.cs, c.o, c.v); f != c.success || got != nil && c.want != nil && !vector.Within(got, c.want) {
+				t.Errorf("SolveRobust() = %v, %v, want = %v, %v", got, f, c.want, c.success)
+			}
+		})
+	}
+}
+
+func TestIntersectRobust(t *testing.T) {
+	// h is the half-plane y ≥ 0, i.e. the characteristic line of h is
+	// directed towards -X.
+	h := *c2d.New(*vector.New(0, 0), *vector.New(0, 1))
+	l := hyperplane.Line(hyperplane.HP(h))
+
+	type config struct {
+		name    string
+		cs      []constraint.C
+		success bool
+		want    segment.S
+	}
+
+	testConfigs := []config{
+		// The constraint d is nearly parallel to h, and intersects
+		// the line of h at x = 1e17, i.e. t = -1e17. The default
+		// solver checks the feasible side of d at a point offset from
+		// the intersection by D(h), which rounds back to the
+		// intersection itself, and therefore clips the wrong end of
+		// the segment.
+		{
+			name:    "NearlyParallel",
+			cs:      []constraint.C{*constraint.New(*c2d.New(*vector.New(0, 1), *vector.New(1e-17, 1)), true)},
+			success: true,
+			want:    *segment.New(l, math.Inf(-1), -1e17),
+		},
+		{
+			name:    "Parallel/Feasible",
+			cs:      []constraint.C{*constraint.New(*c2d.New(*vector.New(0, -1), *vector.New(0, 1)), true)},
+			success: true,
+			want:    *segment.New(l, math.Inf(-1), math.Inf(0)),
+		},
+		{
+			name:    "Parallel/Infeasible",
+			cs:      []constraint.C{*constraint.New(*c2d.New(*vector.New(0, 1), *vector.New(0, 1)), true)},
+			success: false,
+			want:    segment.S{},
+		},
+		{
+			name:    "AntiParallel/Infeasible",
+			cs:      []constraint.C{*constraint.New(*c2d.New(*vector.New(0, -1), *vector.New(0, -1)), true)},
+			success: false,
+			want:    segment.S{},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			r := &region{
+				m:           unbounded.M{},
+				constraints: c.cs,
+				robust:      true,
+			}
+			got, ok := r.intersect(*constraint.New(h, true))
+			if ok != c.success {
+				t.Errorf("intersect() = _, %v, want = _, %v", ok, c.success)
+			}
+
+			if diff := cmp.Diff(
+				c.want,
+				got,
+				cmp.AllowUnexported(
+					segment.S{},
+					l2d.L{},
+					line.L{}),
+				cmpopts.EquateApprox(1e-10, 0)); diff != "" {
+				t.Errorf("intersect() mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+// TestConformance checks the solver against the RVO2 reference implementation
+// of linearProgram2 on randomized sets of constraints, where the solution is
+// bounded by a maximum speed circle.
+//
+// Both solvers report the same feasibility, and for infeasible systems, the
+// same partial solution, i.e. the solution before processing the first
+// constraint which may not be satisfied.
+func TestConformance(t *testing.T) {
+	const (
+		n   = 1000
+		tol = 1e-6
+	)
+
+	// seen tracks if both feasible and infeasible systems have been
+	// sampled.
+	seen := map[bool]bool{}
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		// Constraints with a negative offset contain the origin; the
+		// system is infeasible for roughly half of the inputs.
+		radius := 10 * r.Float64()
+		var cs []constraint.C
+		var ls []l2d.L
+		for j := 0; j < r.Intn(20)+1; j++ {
+			theta := 2 * math.Pi * r.Float64()
+			n := *vector.New(math.Cos(theta), math.Sin(theta))
+			c := *constraint.New(*c2d.New(vector.Scale(radius*(1.5*r.Float64()-1), n), n), true)
+			cs = append(cs, c)
+			ls = append(ls, reference.L(hyperplane.HP(c.C())))
+		}
+		v := *vector.New(20*r.Float64()-10, 20*r.Float64()-10)
+
+		t.Run(fmt.Sprintf("Random-%v", i), func(t *testing.T) {
+			m := circular.New(radius)
+			want, k := reference.LinearProgram2(ls, radius, v, false)
+			got, f := Solve(m, cs, func(s segment.S) vector.V { return s.L().L(s.T(v)) }, m.Clamp(v))
+
+			seen[k == len(ls)] = true
+			if succ := f == feasibility.Feasible; succ != (k == len(ls)) {
+				t.Fatalf("Solve() = _, %v, want feasibility = %v", f, k == len(ls))
+			}
+			if !vector.WithinEpsilon(got, want, epsilon.Absolute(tol)) {
+				t.Errorf("Solve() = %v, want = %v", got, want)
+			}
+		})
+	}
+	if !seen[true] || !seen[false] {
+		t.Errorf("Solve() did not sample both feasible and infeasible systems")
 	}
 }
@@ -129,24 +129,22 @@ func (r *region) Solve(c constraint.C) (vector.V, bool) {
 	v := r.m.V(hyperplane.HP(c.C()).N())
 
 	u, f := solver.Solve(r.m, cs, func(s segment.S) vector.V {
-		// As in hyperplane.Line, we are defining the normal of a line
-		// to be pointing into the feasible region of hyperplane, which
-		// is defined as a vector rotated anti-clockwise to the line direction.
-		c := *constraint.New(*c2d.New(s.L().P(), s.L().N()), true)
-
-		// Find a t-value in the projected constraints which will
-		// minimizes the distance along the Z-axis to the target vector.
-		//
-		// TODO(minkezhang): Verify if any of this statement is true.
+		// The projected problem maximizes the linear objective ⟨v, x⟩,
+		// i.e. moves as far into the feasible region of the
+		// incremental constraint as the projected constraints allow.
+		// The optimum along a segment therefore lies at the end of the
+		// segment in the direction of v.
 		//
 		// This is the optimization function which the official RVO2
 		// implementation has embedded into LP1 for
 		//
 		//   directionOpt = true
 		//
-		// It is not immediately obvious how this function optimizes the
-		// direction from the optimal vector to the constraint plane.
-		if !c.In(v) {
+		// N.B.: RVO2 orients the line direction opposite to
+		// hyperplane.Line, and breaks ties (i.e. when v is
+		// perpendicular to the segment) towards the left extreme of
+		// the line, which is the end at TMax here.
+		if vector.Dot(v, s.L().D()) < 0 {
 			return s.L().L(s.TMin())
 		}
 		return s.L().L(s.TMax())
@@ -184,19 +182,15 @@ func (r *region) project(c constraint.C) ([]constraint.C, bool) {
 
 		i, ok := l.Intersect(m)
 
-		// shared feasible region if the constraint being added
-		// "relaxes" a previous parallel constraint, as the new optimal
-		// solution must lie on the surface of the current incremental
-		// constraint.  We need to check for this condition in the
-		// caller and ensure we do not call Solve() in this case.
+		// If the two constraints are parallel and point in the same
+		// direction, the two 3D constraint planes are also parallel,
+		// and do not intersect. Along the plane of the incremental
+		// constraint, the penetration distance into the previous
+		// constraint is constant, and by construction no larger than
+		// the current slack, so the previous constraint does not bound
+		// the projected problem. As in RVO2, we skip the constraint.
 		if !ok && l.Parallel(m) {
-			if !d.In(hyperplane.HP(c.C()).P()) {
-				r.infeasible = true
-				return nil, r.Feasible()
-			}
-			// The incremental constraint "tightens" the previous
-			// constraint and fully invalidates it.
-			pc = c
+			continue
 		} else if !ok && !l.Parallel(m) {
 			// The two constraints are anti-parallel.
 			//
@@ -205,10 +199,15 @@ func (r *region) project(c constraint.C) ([]constraint.C, bool) {
 			// agents act symmetrically. When calculating
 			// constraints for inflexible walls, we will need to
 			// offset this new plane accordingly.
+			//
+			// As in the intersecting case, the feasible region of
+			// the projected constraint is where the penetration
+			// into D does not exceed the penetration into C, i.e.
+			// the side of the midpoint facing towards D.
 			pc = *constraint.New(
 				*c2d.New(
 					vector.Scale(0.5, vector.Add(l.P(), m.P())),
-					hyperplane.HP(c.C()).N(),
+					vector.Unit(hyperplane.HP(d.C()).N()),
 				),
 				true,
 			)
@@ -289,10 +288,17 @@ func Solve(m M, cs []constraint.C, v vector.V) (vector.V, feasibility.F) {
 			if u, ok := r.Solve(c); ok {
 				v = u
 			}
+
+			// As in RVO2, the slack is only updated when the
+			// solution changes, and is the signed penetration
+			// distance into the new constraint.
+			dist = l.Distance(v)
+			if c.In(v) {
+				dist = -dist
+			}
 		}
 
 		r.Append(c)
-		dist = l.Distance(v)
 	}
 	return v, feasibility.Feasible
 }
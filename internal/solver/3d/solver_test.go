@@ -1,16 +1,25 @@
 package solver
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
 	"github.com/downflux/go-orca/internal/geometry/2d/constraint"
+	"github.com/downflux/go-orca/internal/solver/bounds/circular"
 	"github.com/downflux/go-orca/internal/solver/bounds/unbounded"
+	"github.com/downflux/go-orca/internal/solver/feasibility"
 	"github.com/google/go-cmp/cmp"
 
 	c2d "github.com/downflux/go-geometry/2d/constraint"
+	l2d "github.com/downflux/go-geometry/2d/line"
+	reference "github.com/downflux/go-orca/external/snape/RVO2/solver"
+	s2d "github.com/downflux/go-orca/internal/solver/2d"
 )
 
 func mutable(cs []c2d.C) []constraint.C {
@@ -32,8 +41,11 @@ func TestProject(t *testing.T) {
 		want    []constraint.C
 	}
 	testConfigs := []config{
+		// Parallel constraints which point in the same direction do
+		// not bound the projected problem, regardless of which
+		// constraint is tighter.
 		{
-			name: "Parallel/Override",
+			name: "Parallel/Tighten",
 			cs: mutable(
 				[]c2d.C{
 					*c2d.New(
@@ -50,20 +62,10 @@ func TestProject(t *testing.T) {
 				true,
 			),
 			success: true,
-			want: mutable(
-				[]c2d.C{
-					*c2d.New(
-						*vector.New(0, 1),
-						*vector.New(0, 1),
-					),
-				},
-			),
+			want:    []constraint.C{},
 		},
-		// A parallel constraint which relaxes a previous constraint
-		// is not allowed when calling project(). The caller must make
-		// sure this does not happen.
 		{
-			name: "Parallel/Infeasible",
+			name: "Parallel/Relax",
 			cs: mutable(
 				[]c2d.C{
 					*c2d.New(
@@ -79,8 +81,8 @@ func TestProject(t *testing.T) {
 				),
 				true,
 			),
-			success: false,
-			want:    nil,
+			success: true,
+			want:    []constraint.C{},
 		},
 		// Test we can construct the appropriate plane-plane
 		// intersection for two constraints with a shared feasible
@@ -103,11 +105,15 @@ func TestProject(t *testing.T) {
 				true,
 			),
 			success: true,
+			// The projected constraint bisects the two
+			// constraints, and is feasible where the penetration
+			// into the pre-existing constraint does not exceed the
+			// penetration into the input constraint.
 			want: mutable(
 				[]c2d.C{
 					*c2d.New(
 						*vector.New(0, 0.5),
-						*vector.New(0, -1),
+						*vector.New(0, 1),
 					),
 				},
 			),
@@ -132,11 +138,15 @@ func TestProject(t *testing.T) {
 				true,
 			),
 			success: true,
+			// The projected constraint bisects the two
+			// constraints, and is feasible where the penetration
+			// into the pre-existing constraint does not exceed the
+			// penetration into the input constraint.
 			want: mutable(
 				[]c2d.C{
 					*c2d.New(
 						*vector.New(0, 0.5),
-						*vector.New(0, -1),
+						*vector.New(0, 1),
 					),
 				},
 			),
@@ -292,7 +302,7 @@ func TestSolve(t *testing.T) {
 		// intersecting constraints.
 		{
 			name: "AntiParallel/Intersect",
-			m:    unbounded.M{},
+			m:    circular.New(10),
 			cs: mutable(
 				[]c2d.C{
 					*c2d.New(
@@ -309,22 +319,21 @@ func TestSolve(t *testing.T) {
 				true,
 			),
 			success: true,
-			// The 2D projection of the single pre-existing
-			// constraint is parallel to the input constraint, and
-			// covers the input constraint; thus, any solution which
-			// fulfills the input constraint also fulfills the
-			// projected constraint.
-			//
-			// The default optimization vector for the 2D problem is
-			// a vector pointing to the normal the input constraint.
-			want: *vector.New(0, 1),
+			// The projected constraint is the line y = 0.5, which
+			// is equidistant to both constraints. As in RVO2, the
+			// solution is the extreme point of the projected
+			// constraint in the direction of the normal of the
+			// input constraint; as the normal is perpendicular to
+			// the projected constraint, ties are broken towards
+			// the end of the segment at TMax.
+			want: *vector.New(math.Sqrt(100-0.25), 0.5),
 		},
 
 		// Ensure that we can find a solution for two disjoint
 		// constraints.
 		{
 			name: "AntiParallel/Disjoint",
-			m:    unbounded.M{},
+			m:    circular.New(10),
 			cs: mutable(
 				[]c2d.C{
 					*c2d.New(
@@ -341,15 +350,10 @@ func TestSolve(t *testing.T) {
 				true,
 			),
 			success: true,
-			// The 2D projection of the single pre-existing
-			// constraint is parallel to the input constraint, and
-			// covers the input constraint; thus, any solution which
-			// fulfills the input constraint also fulfills the
-			// projected constraint.
-			//
-			// The default optimization vector for the 2D problem is
-			// a vector pointing to the normal the input constraint.
-			want: *vector.New(0, -1),
+			// The solution penetrates both constraints equally,
+			// i.e. lies on the line y = 0.5. See
+			// AntiParallel/Intersect.
+			want: *vector.New(-math.Sqrt(100-0.25), 0.5),
 		},
 	}
 
@@ -380,6 +384,16 @@ func TestSolve(t *testing.T) {
 			*vector.New(0, -1),
 		)
 
+		// The solution minimizes the maximum penetration distance p
+		// into the three constraints, i.e. the solution penetrates all
+		// three constraints equally --
+		//
+		//   (a)  2x - y + 6 = √5 p
+		//   (b) -3x - y + 6 = √10 p
+		//   (c)       y - 1 = p
+		p := 25 / (3*math.Sqrt(5) + 2*math.Sqrt(10) + 5)
+		want := *vector.New(((math.Sqrt(5)+1)*p-5)/2, 1+p)
+
 		return []config{
 			{
 				name:    "2DInfeasible",
@@ -387,7 +401,7 @@ func TestSolve(t *testing.T) {
 				cs:      mutable([]c2d.C{a, b}),
 				c:       *constraint.New(c, true),
 				success: true,
-				want:    *vector.New(math.Inf(0), math.Inf(0)),
+				want:    want,
 			},
 			// Test that when adding a new constraint into the 3D
 			// region, the order of the previous constraints added
@@ -398,7 +412,7 @@ func TestSolve(t *testing.T) {
 				cs:      mutable([]c2d.C{b, a}),
 				c:       *constraint.New(c, true),
 				success: true,
-				want:    *vector.New(math.Inf(0), math.Inf(0)),
+				want:    want,
 			},
 		}
 	}()...)
@@ -417,3 +431,115 @@ func TestSolve(t *testing.T) {
 		})
 	}
 }
+
+// TestConformance checks the 3D solver against the RVO2 reference
+// implementation of linearProgram3 on infeasible sets of constraints, where the
+// first k constraints are immutable obstacle constraints, and the solution is
+// bounded by a maximum speed circle.
+func TestConformance(t *testing.T) {
+	const (
+		n   = 1000
+		tol = 1e-6
+	)
+
+	type config struct {
+		name string
+		cs   []constraint.C
+		k    int
+		r    float64
+		v    vector.V
+	}
+
+	// hp constructs a mutable constraint with a unit normal.
+	hp := func(p vector.V, n vector.V) constraint.C {
+		return *constraint.New(*c2d.New(p, vector.Unit(n)), true)
+	}
+
+	testConfigs := []config{
+		{
+			name: "Parallel/Tighten",
+			cs: []constraint.C{
+				hp(*vector.New(0, 1), *vector.New(0, 1)),
+				hp(*vector.New(0, -1), *vector.New(0, -1)),
+				hp(*vector.New(0, -2), *vector.New(0, -1)),
+			},
+			r: 10,
+			v: *vector.New(3, 4),
+		},
+		{
+			name: "Parallel/Relax",
+			cs: []constraint.C{
+				hp(*vector.New(0, 1), *vector.New(0, 1)),
+				hp(*vector.New(0, -2), *vector.New(0, -1)),
+				hp(*vector.New(0, -1), *vector.New(0, -1)),
+			},
+			r: 10,
+			v: *vector.New(3, 4),
+		},
+		{
+			name: "Parallel/Feasible",
+			cs: []constraint.C{
+				hp(*vector.New(0, 1), *vector.New(0, 1)),
+				hp(*vector.New(0, -1), *vector.New(0, -1)),
+				hp(*vector.New(1, 0), *vector.New(1, 0)),
+				hp(*vector.New(2, 0), *vector.New(1, 0)),
+			},
+			r: 10,
+			v: *vector.New(3, 4),
+		},
+	}
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		c := config{
+			name: fmt.Sprintf("Random-%v", i),
+			k:    r.Intn(3),
+			r:    10 * r.Float64(),
+			v:    *vector.New(20*r.Float64()-10, 20*r.Float64()-10),
+		}
+
+		// Obstacle constraints always contain the origin, and are
+		// therefore feasible, as assumed by RVO2.
+		for j := 0; j < c.k+r.Intn(20)+2; j++ {
+			theta := 2 * math.Pi * r.Float64()
+			n := *vector.New(math.Cos(theta), math.Sin(theta))
+			d := c.r * (1.5*r.Float64() - 1)
+			if j < c.k {
+				d = -c.r * r.Float64()
+			}
+			c.cs = append(c.cs, *constraint.New(*c2d.New(vector.Scale(d, n), n), j >= c.k))
+		}
+		testConfigs = append(testConfigs, c)
+	}
+
+	// infeasible tracks the number of test cases which exercise the 3D
+	// solver.
+	var infeasible int
+	for _, c := range testConfigs {
+		m := circular.New(c.r)
+		u, f := s2d.Solve(m, c.cs, func(s segment.S) vector.V { return s.L().L(s.T(c.v)) }, m.Clamp(c.v))
+		if f == feasibility.Feasible {
+			continue
+		}
+		infeasible++
+
+		t.Run(c.name, func(t *testing.T) {
+			var ls []l2d.L
+			for _, d := range c.cs {
+				ls = append(ls, reference.L(hyperplane.HP(d.C())))
+			}
+			want := reference.Solve(ls, c.k, c.r, c.v)
+
+			got, f := Solve(m, c.cs, u)
+			if f != feasibility.Feasible {
+				t.Fatalf("Solve() = _, %v, want = _, %v", f, feasibility.Feasible)
+			}
+			if !vector.WithinEpsilon(got, want, epsilon.Absolute(tol)) {
+				t.Errorf("Solve() = %v, want = %v", got, want)
+			}
+		})
+	}
+	if infeasible < n/4 {
+		t.Errorf("only %v / %v test cases were infeasible", infeasible, len(testConfigs))
+	}
+}
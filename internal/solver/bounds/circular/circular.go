@@ -57,3 +57,11 @@ func (m M) V(v vector.V) vector.V {
 	}
 	return vector.Scale(r, vector.Unit(v))
 }
+
+// Clamp returns the point in the circle which is closest to the input vector.
+func (m M) Clamp(v vector.V) vector.V {
+	if m.In(v) {
+		return v
+	}
+	return m.V(v)
+}
@@ -0,0 +1,163 @@
+// Package ellipse defines a 2D bounding constraint that limits the solution
+// vector to an (arbitrarily oriented) ellipse.
+//
+// This is useful for e.g. agents which may move faster along their heading than
+// sideways. Offsetting the center of the ellipse along the heading allows
+// asymmetric forward and reverse speed limits.
+package ellipse
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tolerance is the rounding tolerance used when checking if a vector lies
+// within the ellipse.
+const tolerance = 1e-5
+
+// M defines a 2D elliptical bounding constraint.
+type M struct {
+	// c is the center of the ellipse.
+	c vector.V
+
+	// a and b are the semi-axis lengths of the ellipse along the principal
+	// axis h and the perpendicular axis respectively.
+	a float64
+	b float64
+
+	// h is the unit principal axis of the ellipse.
+	h vector.V
+}
+
+// New constructs an ellipse centered at c, with semi-axis a along the
+// direction h, and semi-axis b perpendicular to h. New returns an error if
+// either semi-axis is non-positive, or if h is the zero vector.
+func New(c vector.V, a float64, b float64, h vector.V) (*M, error) {
+	if a <= 0 || b <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot construct an ellipse with non-positive semi-axes (%v, %v)", a, b)
+	}
+	if vector.Within(h, *vector.New(0, 0)) {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot construct an ellipse with a zero-length principal axis")
+	}
+	return &M{
+		c: c,
+		a: a,
+		b: b,
+		h: vector.Unit(h),
+	}, nil
+}
+
+// Bound returns the line (segment) of intersection between the ellipse and the
+// input constraint.
+//
+// The ellipse is the image of the unit circle under an affine transformation;
+// as affine transformations preserve the parametric t-values of a line, we
+// may solve for the intersection in the unit circle frame directly.
+func (m M) Bound(c constraint.C) (segment.S, bool) {
+	l := hyperplane.Line(hyperplane.HP(c))
+
+	p := m.local(vector.Sub(l.P(), m.c))
+	d := m.local(l.D())
+
+	// Solve |p + td|² = 1.
+	qa := vector.SquaredMagnitude(d)
+	qb := 2 * vector.Dot(p, d)
+	qc := vector.SquaredMagnitude(p) - 1
+
+	disc := qb*qb - 4*qa*qc
+	if disc < 0 && !epsilon.Within(disc, 0) {
+		return segment.S{}, false
+	}
+	disc = math.Sqrt(math.Max(0, disc))
+
+	return *segment.New(l, (-qb-disc)/(2*qa), (-qb+disc)/(2*qa)), true
+}
+
+// In checks if the input vector is contained within the ellipse.
+func (m M) In(v vector.V) bool {
+	return vector.SquaredMagnitude(m.local(vector.Sub(v, m.c))) <= 1+tolerance
+}
+
+// V returns the support point of the ellipse in the direction of the input
+// vector, i.e. the point in the ellipse which is furthest along the input
+// direction.
+func (m M) V(v vector.V) vector.V {
+	n := m.perpendicular()
+
+	// The support point of the unit circle in the transformed frame is
+	// parallel to the transformed direction (a⟨v, h⟩, b⟨v, n⟩).
+	w := *vector.New(m.a*vector.Dot(v, m.h), m.b*vector.Dot(v, n))
+	if vector.Within(w, *vector.New(0, 0)) {
+		w = *vector.New(1, 0)
+	}
+	return m.global(vector.Unit(w))
+}
+
+// Clamp returns the point in the ellipse which is closest to the input vector.
+//
+// Given a point p = (x, y) outside the ellipse in the ellipse frame, the
+// closest point on the ellipse is
+//
+//	q(t) = (a²x / (t + a²), b²y / (t + b²))
+//
+// for the unique t > 0 for which q(t) lies on the ellipse; we find t via
+// bisection.
+func (m M) Clamp(v vector.V) vector.V {
+	if m.In(v) {
+		return v
+	}
+
+	w := vector.Sub(v, m.c)
+	x, y := vector.Dot(w, m.h), vector.Dot(w, m.perpendicular())
+
+	q := func(t float64) (float64, float64) {
+		return m.a * m.a * x / (t + m.a*m.a), m.b * m.b * y / (t + m.b*m.b)
+	}
+	f := func(t float64) float64 {
+		qx, qy := q(t)
+		return (qx/m.a)*(qx/m.a) + (qy/m.b)*(qy/m.b) - 1
+	}
+
+	tmin, tmax := 0.0, math.Sqrt(m.a*m.a*x*x+m.b*m.b*y*y)
+	for i := 0; i < 128; i++ {
+		if t := (tmin + tmax) / 2; f(t) > 0 {
+			tmin = t
+		} else {
+			tmax = t
+		}
+	}
+
+	qx, qy := q((tmin + tmax) / 2)
+	return m.global(*vector.New(qx/m.a, qy/m.b))
+}
+
+// perpendicular returns the unit secondary axis of the ellipse.
+func (m M) perpendicular() vector.V { return *vector.New(-m.h.Y(), m.h.X()) }
+
+// local transforms the input relative vector into the frame in which the
+// ellipse is the unit circle.
+func (m M) local(v vector.V) vector.V {
+	return *vector.New(
+		vector.Dot(v, m.h)/m.a,
+		vector.Dot(v, m.perpendicular())/m.b,
+	)
+}
+
+// global transforms the input vector in the unit circle frame back into the
+// ambient frame.
+func (m M) global(v vector.V) vector.V {
+	return vector.Add(
+		m.c,
+		vector.Add(
+			vector.Scale(m.a*v.X(), m.h),
+			vector.Scale(m.b*v.Y(), m.perpendicular()),
+		),
+	)
+}
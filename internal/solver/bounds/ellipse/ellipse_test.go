@@ -0,0 +1,134 @@
+package ellipse
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+
+	s2d "github.com/downflux/go-orca/internal/solver/2d"
+	s3d "github.com/downflux/go-orca/internal/solver/3d"
+)
+
+var (
+	_ s2d.M = M{}
+	_ s3d.M = M{}
+)
+
+func TestBound(t *testing.T) {
+	// m is the ellipse x² / 4 + y² = 1, rotated by 90 degrees and
+	// translated by (1, 0).
+	m, _ := New(*vector.New(1, 0), 2, 1, *vector.New(0, 1))
+
+	type config struct {
+		name    string
+		c       constraint.C
+		success bool
+		want    []vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name:    "Major",
+			c:       *constraint.New(*vector.New(1, 0), *vector.New(1, 0)),
+			success: true,
+			want:    []vector.V{*vector.New(1, -2), *vector.New(1, 2)},
+		},
+		{
+			name:    "Minor",
+			c:       *constraint.New(*vector.New(0, 0), *vector.New(0, 1)),
+			success: true,
+			want:    []vector.V{*vector.New(0, 0), *vector.New(2, 0)},
+		},
+		{
+			name:    "Tangent",
+			c:       *constraint.New(*vector.New(2, 0), *vector.New(1, 0)),
+			success: true,
+			want:    []vector.V{*vector.New(2, 0), *vector.New(2, 0)},
+		},
+		{
+			name:    "Outside",
+			c:       *constraint.New(*vector.New(3, 0), *vector.New(1, 0)),
+			success: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			s, ok := m.Bound(c.c)
+			if ok != c.success {
+				t.Fatalf("Bound() = _, %v, want = _, %v", ok, c.success)
+			}
+			if !ok {
+				return
+			}
+			got := []vector.V{s.L().L(s.TMin()), s.L().L(s.TMax())}
+			if !(vector.Within(got[0], c.want[0]) && vector.Within(got[1], c.want[1])) && !(vector.Within(got[0], c.want[1]) && vector.Within(got[1], c.want[0])) {
+				t.Errorf("Bound() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestV(t *testing.T) {
+	m, _ := New(*vector.New(1, 0), 2, 1, *vector.New(0, 1))
+
+	type config struct {
+		name string
+		v    vector.V
+		want vector.V
+	}
+
+	testConfigs := []config{
+		{name: "Major", v: *vector.New(0, 1), want: *vector.New(1, 2)},
+		{name: "Minor", v: *vector.New(-1, 0), want: *vector.New(0, 0)},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := m.V(c.v); !vector.Within(got, c.want) {
+				t.Errorf("V() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestClamp checks that the clamped vector lies on the boundary of the ellipse,
+// and that the vector from the clamped point to the input is normal to the
+// ellipse.
+func TestClamp(t *testing.T) {
+	const n = 1000
+
+	m, _ := New(*vector.New(1, 0), 3, 1, *vector.New(1, 1))
+	r := rand.New(rand.NewSource(0))
+
+	for i := 0; i < n; i++ {
+		v := *vector.New(20*r.Float64()-10, 20*r.Float64()-10)
+		got := m.Clamp(v)
+
+		if m.In(v) {
+			if !vector.Within(got, v) {
+				t.Errorf("Clamp(%v) = %v, want = %v", v, got, v)
+			}
+			continue
+		}
+
+		if l := vector.SquaredMagnitude(m.local(vector.Sub(got, m.c))); !epsilon.Absolute(1e-8).Within(l, 1) {
+			t.Errorf("Clamp(%v) = %v, which does not lie on the ellipse boundary", v, got)
+		}
+
+		// The outward normal of the ellipse at q is proportional to
+		// the gradient (⟨q, h⟩ / a², ⟨q, n⟩ / b²).
+		q := vector.Sub(got, m.c)
+		g := vector.Add(
+			vector.Scale(vector.Dot(q, m.h)/(m.a*m.a), m.h),
+			vector.Scale(vector.Dot(q, m.perpendicular())/(m.b*m.b), m.perpendicular()),
+		)
+		if d := math.Abs(vector.Determinant(vector.Unit(g), vector.Unit(vector.Sub(v, got)))); d > 1e-6 {
+			t.Errorf("Clamp(%v) = %v, which is not the closest point on the ellipse", v, got)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package intersection defines a 2D bounding constraint that limits the
+// solution vector to the intersection of two convex bounding constraints.
+//
+// This is useful for e.g. acceleration-constrained agents with non-circular
+// velocity bounds, where the set of reachable velocities in the next timestep is
+// the intersection of the agent velocity bounds and the circle of velocities
+// reachable from the current agent velocity.
+package intersection
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// iterations is the maximum number of alternating projections
+	// calculated when clamping a vector into the intersection, and the
+	// number of bisection steps when calculating the support point.
+	iterations = 128
+
+	// tolerance is the convergence threshold of the alternating
+	// projections.
+	tolerance = 1e-10
+)
+
+// B is a convex bounding constraint; this is the same set of methods required
+// by the internal solvers.
+type B interface {
+	Bound(c constraint.C) (segment.S, bool)
+	In(v vector.V) bool
+	V(v vector.V) vector.V
+	Clamp(v vector.V) vector.V
+}
+
+// M defines a 2D bounding constraint which is the intersection of two convex
+// bounding constraints.
+type M struct {
+	a B
+	b B
+}
+
+// New constructs a new intersection of the two input bounding constraints. New
+// returns an error if the two bounds do not intersect.
+func New(a B, b B) (*M, error) {
+	m := &M{a: a, b: b}
+	if v := m.Clamp(a.V(*vector.New(1, 0))); !m.In(v) {
+		return nil, status.Errorf(codes.OutOfRange, "cannot construct an intersection from two disjoint bounds")
+	}
+	return m, nil
+}
+
+// Bound returns the line (segment) of intersection between the input
+// constraint and both underlying bounds.
+//
+// N.B.: All bounding constraints parameterize the segment by the
+// characteristic line of the input constraint, so the t-values of the
+// individual segments are directly comparable.
+func (m M) Bound(c constraint.C) (segment.S, bool) {
+	s, ok := m.a.Bound(c)
+	if !ok {
+		return segment.S{}, false
+	}
+	t, ok := m.b.Bound(c)
+	if !ok {
+		return segment.S{}, false
+	}
+
+	tmin, tmax := math.Max(s.TMin(), t.TMin()), math.Min(s.TMax(), t.TMax())
+	if tmin > tmax && !epsilon.Within(tmin, tmax) {
+		return segment.S{}, false
+	}
+	return *segment.New(s.L(), tmin, math.Max(tmin, tmax)), true
+}
+
+// In checks if the input vector is contained within both underlying bounds.
+func (m M) In(v vector.V) bool { return m.a.In(v) && m.b.In(v) }
+
+// V returns the support point of the intersection in the direction of the
+// input vector.
+//
+// The support value h, i.e. the maximum of ⟨u, x⟩ over the intersection, is the
+// largest value for which the line ⟨u, x⟩ = h intersects both bounds; we find
+// h via bisection, and return the midpoint of the final segment of
+// intersection.
+func (m M) V(v vector.V) vector.V {
+	u := vector.Unit(v)
+	if vector.Within(v, *vector.New(0, 0)) {
+		u = *vector.New(1, 0)
+	}
+
+	// bound returns the segment of intersection between the line
+	// ⟨u, x⟩ = h and the bounding constraint.
+	bound := func(h float64) (segment.S, bool) {
+		return m.Bound(*constraint.New(vector.Scale(h, u), u))
+	}
+
+	// Both underlying support points bound the support value of the
+	// intersection from above, and any point in the intersection bounds it
+	// from below.
+	hmax := math.Min(vector.Dot(u, m.a.V(u)), vector.Dot(u, m.b.V(u)))
+	hmin := vector.Dot(u, m.Clamp(m.a.V(u)))
+
+	s, ok := bound(hmax)
+	if ok {
+		return s.L().L((s.TMin() + s.TMax()) / 2)
+	}
+	if s, ok = bound(hmin); !ok {
+		return m.Clamp(m.a.V(u))
+	}
+
+	for i := 0; i < iterations; i++ {
+		h := (hmin + hmax) / 2
+		if t, ok := bound(h); ok {
+			s, hmin = t, h
+		} else {
+			hmax = h
+		}
+	}
+	return s.L().L((s.TMin() + s.TMax()) / 2)
+}
+
+// Clamp returns the point in the intersection which is closest to the input
+// vector.
+//
+// We use Dykstra's alternating projection algorithm, which, unlike naive
+// alternating projections, converges to the closest point in the intersection
+// rather than to an arbitrary point in the intersection.
+func (m M) Clamp(v vector.V) vector.V {
+	if m.In(v) {
+		return v
+	}
+
+	x := v
+	p, q := *vector.New(0, 0), *vector.New(0, 0)
+	for i := 0; i < iterations; i++ {
+		y := m.a.Clamp(vector.Add(x, p))
+		p = vector.Sub(vector.Add(x, p), y)
+
+		z := m.b.Clamp(vector.Add(y, q))
+		q = vector.Sub(vector.Add(y, q), z)
+
+		d := vector.SquaredMagnitude(vector.Sub(z, x))
+		x = z
+		if d < tolerance*tolerance && m.a.In(x) {
+			break
+		}
+	}
+	return x
+}
@@ -0,0 +1,87 @@
+package intersection
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/hypersphere"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/internal/solver/bounds/circular"
+	"github.com/downflux/go-orca/internal/solver/bounds/lens"
+	"github.com/downflux/go-orca/internal/solver/bounds/polygon"
+
+	s2d "github.com/downflux/go-orca/internal/solver/2d"
+	s3d "github.com/downflux/go-orca/internal/solver/3d"
+)
+
+var (
+	_ s2d.M = M{}
+	_ s3d.M = M{}
+)
+
+func square(r float64) B {
+	m, _ := polygon.New([]vector.V{
+		*vector.New(-r, -r),
+		*vector.New(r, -r),
+		*vector.New(r, r),
+		*vector.New(-r, r),
+	})
+	return *m
+}
+
+func TestNew(t *testing.T) {
+	d, _ := lens.New(
+		*hypersphere.New(*vector.New(5, 0), 1),
+		*hypersphere.New(*vector.New(5, 0), 1),
+	)
+	if _, err := New(square(1), *d); err == nil {
+		t.Errorf("New() = _, %v, want a non-nil error", err)
+	}
+	if _, err := New(square(1), *circular.New(1)); err != nil {
+		t.Errorf("New() = _, %v, want = _, %v", err, nil)
+	}
+}
+
+// TestLens checks that the intersection of two circles matches the lens
+// bounding constraint.
+func TestLens(t *testing.T) {
+	const n = 1000
+
+	a := *hypersphere.New(*vector.New(0, 0), 2)
+	b := *hypersphere.New(*vector.New(2, 1), 2)
+
+	l, _ := lens.New(a, b)
+	da, _ := lens.New(a, a)
+	db, _ := lens.New(b, b)
+	m, err := New(*da, *db)
+	if err != nil {
+		t.Fatalf("New() = _, %v, want = _, %v", err, nil)
+	}
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		v := *vector.New(10*r.Float64()-5, 10*r.Float64()-5)
+
+		if got, want := m.In(v), l.In(v); got != want {
+			t.Errorf("In(%v) = %v, want = %v", v, got, want)
+		}
+		if got, want := m.Clamp(v), l.Clamp(v); !vector.WithinEpsilon(got, want, epsilon.Absolute(1e-6)) {
+			t.Errorf("Clamp(%v) = %v, want = %v", v, got, want)
+		}
+		if got, want := m.V(v), l.V(v); !epsilon.Absolute(1e-6).Within(vector.Dot(got, v), vector.Dot(want, v)) {
+			t.Errorf("V(%v) = %v, want = %v", v, got, want)
+		}
+
+		c := *constraint.New(v, *vector.New(r.Float64()-0.5, r.Float64()-0.5))
+		s, ok := m.Bound(c)
+		u, want := l.Bound(c)
+		if ok != want {
+			t.Errorf("Bound(%v) = _, %v, want = _, %v", c, ok, want)
+		}
+		if ok && want && !(epsilon.Absolute(1e-6).Within(s.TMin(), u.TMin()) && epsilon.Absolute(1e-6).Within(s.TMax(), u.TMax())) {
+			t.Errorf("Bound(%v) = %v, want = %v", c, s, u)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package lens defines a 2D bounding constraint that limits the solution vector
+// to the intersection of two circles.
+//
+// This is useful for e.g. acceleration-constrained agents, where the set of
+// reachable velocities in the next timestep is the intersection of the maximum
+// speed circle (centered at the origin) and a circle centered at the current
+// agent velocity, with a radius equal to the maximum change in velocity over
+// the timestep.
+package lens
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/hypersphere"
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// M defines a 2D bounding constraint which is the intersection of two circles.
+type M struct {
+	a hypersphere.C
+	b hypersphere.C
+}
+
+// New constructs a new lens-shaped bounding constraint. New returns an error
+// if the two circles do not intersect.
+func New(a hypersphere.C, b hypersphere.C) (*M, error) {
+	if d := vector.Magnitude(vector.Sub(a.P(), b.P())); d > a.R()+b.R() && !epsilon.Within(d, a.R()+b.R()) {
+		return nil, status.Errorf(codes.OutOfRange, "cannot construct a lens from two disjoint circles")
+	}
+	return &M{a: a, b: b}, nil
+}
+
+// Bound returns the line (segment) of intersection between the lens constraint
+// and the input constraint.
+func (m M) Bound(c constraint.C) (segment.S, bool) {
+	l := hyperplane.Line(hyperplane.HP(c))
+
+	tmin, tmax := math.Inf(-1), math.Inf(0)
+	for _, d := range []hypersphere.C{m.a, m.b} {
+		v1, v2, ok := l.IntersectCircle(d)
+		if !ok {
+			return segment.S{}, false
+		}
+		t1, t2 := l.T(v1), l.T(v2)
+		tmin = math.Max(tmin, math.Min(t1, t2))
+		tmax = math.Min(tmax, math.Max(t1, t2))
+	}
+
+	s := *segment.New(l, tmin, tmax)
+	if !s.Feasible() {
+		return segment.S{}, false
+	}
+	return s, true
+}
+
+// In checks if the input vector is contained within the lens.
+func (m M) In(v vector.V) bool { return in(m.a, v) && in(m.b, v) }
+
+// V returns the support point of the lens in the direction of the input
+// vector, i.e. the point in the lens which is furthest along the input
+// direction. The returned point lies on the boundary of the lens.
+func (m M) V(v vector.V) vector.V {
+	u := vector.Unit(v)
+
+	var cs []vector.V
+	for _, d := range [][]hypersphere.C{{m.a, m.b}, {m.b, m.a}} {
+		if p := vector.Add(d[0].P(), vector.Scale(d[0].R(), u)); in(d[1], p) {
+			cs = append(cs, p)
+		}
+	}
+	cs = append(cs, m.corners()...)
+
+	return argmax(cs, func(w vector.V) float64 { return vector.Dot(u, w) })
+}
+
+// Clamp returns the point in the lens which is closest to the input vector.
+func (m M) Clamp(v vector.V) vector.V {
+	if m.In(v) {
+		return v
+	}
+
+	var cs []vector.V
+	for _, d := range [][]hypersphere.C{{m.a, m.b}, {m.b, m.a}} {
+		if p := project(d[0], v); in(d[1], p) {
+			cs = append(cs, p)
+		}
+	}
+	cs = append(cs, m.corners()...)
+
+	return argmax(cs, func(w vector.V) float64 { return -vector.SquaredMagnitude(vector.Sub(v, w)) })
+}
+
+// corners returns the points of intersection between the two circle
+// boundaries. If one circle fully contains the other, the intersection is
+// empty.
+func (m M) corners() []vector.V {
+	p := vector.Sub(m.b.P(), m.a.P())
+	d := vector.Magnitude(p)
+
+	// The circles are concentric or nested, and their boundaries do not
+	// intersect.
+	if d == 0 || d < math.Abs(m.a.R()-m.b.R()) {
+		return nil
+	}
+
+	// x is the distance along p from the center of a to the chord joining
+	// the two corners, and h is the half-length of the chord.
+	x := (d*d + m.a.R()*m.a.R() - m.b.R()*m.b.R()) / (2 * d)
+	h := math.Sqrt(math.Max(0, m.a.R()*m.a.R()-x*x))
+
+	u := vector.Unit(p)
+	c := vector.Add(m.a.P(), vector.Scale(x, u))
+	n := *vector.New(-u.Y(), u.X())
+
+	return []vector.V{
+		vector.Add(c, vector.Scale(h, n)),
+		vector.Sub(c, vector.Scale(h, n)),
+	}
+}
+
+// in checks if the input vector lies within the circle, with some tolerance for
+// rounding errors at the boundary.
+func in(c hypersphere.C, v vector.V) bool {
+	return c.In(v) || epsilon.Absolute(1e-5).Within(
+		vector.SquaredMagnitude(vector.Sub(v, c.P())),
+		c.R()*c.R(),
+	)
+}
+
+// project returns the point on the boundary of the circle closest to the input
+// vector.
+func project(c hypersphere.C, v vector.V) vector.V {
+	w := vector.Sub(v, c.P())
+	if vector.SquaredMagnitude(w) == 0 {
+		w = *vector.New(1, 0)
+	}
+	return vector.Add(c.P(), vector.Scale(c.R(), vector.Unit(w)))
+}
+
+// argmax returns the candidate vector which maximizes the input function.
+func argmax(vs []vector.V, f func(v vector.V) float64) vector.V {
+	var u vector.V
+	g := math.Inf(-1)
+	for _, v := range vs {
+		if h := f(v); h > g {
+			u, g = v, h
+		}
+	}
+	return u
+}
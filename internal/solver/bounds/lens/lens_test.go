@@ -0,0 +1,201 @@
+package lens
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/hypersphere"
+	"github.com/downflux/go-geometry/2d/vector"
+
+	s2d "github.com/downflux/go-orca/internal/solver/2d"
+	s3d "github.com/downflux/go-orca/internal/solver/3d"
+)
+
+var (
+	_ s2d.M = M{}
+	_ s3d.M = M{}
+)
+
+func TestNew(t *testing.T) {
+	type config struct {
+		name    string
+		a       hypersphere.C
+		b       hypersphere.C
+		success bool
+	}
+
+	testConfigs := []config{
+		{
+			name:    "Intersecting",
+			a:       *hypersphere.New(*vector.New(0, 0), 2),
+			b:       *hypersphere.New(*vector.New(2, 0), 2),
+			success: true,
+		},
+		{
+			name:    "Tangent",
+			a:       *hypersphere.New(*vector.New(0, 0), 1),
+			b:       *hypersphere.New(*vector.New(2, 0), 1),
+			success: true,
+		},
+		{
+			name:    "Nested",
+			a:       *hypersphere.New(*vector.New(0, 0), 10),
+			b:       *hypersphere.New(*vector.New(2, 0), 1),
+			success: true,
+		},
+		{
+			name:    "Disjoint",
+			a:       *hypersphere.New(*vector.New(0, 0), 1),
+			b:       *hypersphere.New(*vector.New(3, 0), 1),
+			success: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := New(c.a, c.b); (err == nil) != c.success {
+				t.Errorf("New() = _, %v, want success = %v", err, c.success)
+			}
+		})
+	}
+}
+
+func TestBound(t *testing.T) {
+	// m is the lens formed by two circles of radius 2 centered at (±1, 0),
+	// whose corners lie at (0, ±√3).
+	m, err := New(
+		*hypersphere.New(*vector.New(-1, 0), 2),
+		*hypersphere.New(*vector.New(1, 0), 2),
+	)
+	if err != nil {
+		t.Fatalf("New() = _, %v, want = _, %v", err, nil)
+	}
+
+	type config struct {
+		name    string
+		c       constraint.C
+		success bool
+		min     vector.V
+		max     vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name:    "Vertical",
+			c:       *constraint.New(*vector.New(0, 0), *vector.New(1, 0)),
+			success: true,
+			min:     *vector.New(0, -math.Sqrt(3)),
+			max:     *vector.New(0, math.Sqrt(3)),
+		},
+		{
+			name:    "Horizontal",
+			c:       *constraint.New(*vector.New(0, 0), *vector.New(0, 1)),
+			success: true,
+			min:     *vector.New(1, 0),
+			max:     *vector.New(-1, 0),
+		},
+		{
+			// The line x = 1.5 intersects the circle centered at
+			// (1, 0), but not the circle centered at (-1, 0).
+			name:    "Outside",
+			c:       *constraint.New(*vector.New(1.5, 0), *vector.New(1, 0)),
+			success: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			s, ok := m.Bound(c.c)
+			if ok != c.success {
+				t.Fatalf("Bound() = _, %v, want = _, %v", ok, c.success)
+			}
+			if !ok {
+				return
+			}
+			if got := s.L().L(s.TMin()); !vector.Within(got, c.min) {
+				t.Errorf("Bound().L(TMin()) = %v, want = %v", got, c.min)
+			}
+			if got := s.L().L(s.TMax()); !vector.Within(got, c.max) {
+				t.Errorf("Bound().L(TMax()) = %v, want = %v", got, c.max)
+			}
+		})
+	}
+}
+
+func TestV(t *testing.T) {
+	m, err := New(
+		*hypersphere.New(*vector.New(-1, 0), 2),
+		*hypersphere.New(*vector.New(1, 0), 2),
+	)
+	if err != nil {
+		t.Fatalf("New() = _, %v, want = _, %v", err, nil)
+	}
+
+	type config struct {
+		name string
+		v    vector.V
+		want vector.V
+	}
+
+	testConfigs := []config{
+		{name: "Edge", v: *vector.New(1, 0), want: *vector.New(1, 0)},
+		{name: "Corner", v: *vector.New(0, 1), want: *vector.New(0, math.Sqrt(3))},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got := m.V(c.v)
+			if !vector.Within(got, c.want) {
+				t.Errorf("V() = %v, want = %v", got, c.want)
+			}
+			if !m.In(got) {
+				t.Errorf("In() = %v, want = %v", false, true)
+			}
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	type config struct {
+		name string
+		m    M
+		v    vector.V
+		want vector.V
+	}
+
+	lens := *must(New(
+		*hypersphere.New(*vector.New(-1, 0), 2),
+		*hypersphere.New(*vector.New(1, 0), 2),
+	))
+
+	testConfigs := []config{
+		{name: "In", m: lens, v: *vector.New(0.5, 0.5), want: *vector.New(0.5, 0.5)},
+		{name: "Edge", m: lens, v: *vector.New(3, 0), want: *vector.New(1, 0)},
+		{name: "Corner", m: lens, v: *vector.New(0, 5), want: *vector.New(0, math.Sqrt(3))},
+		{
+			name: "Nested",
+			m: *must(New(
+				*hypersphere.New(*vector.New(0, 0), 10),
+				*hypersphere.New(*vector.New(2, 0), 1),
+			)),
+			v:    *vector.New(0, 0),
+			want: *vector.New(1, 0),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.m.Clamp(c.v); !vector.Within(got, c.want) {
+				t.Errorf("Clamp() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func must(m *M, err error) *M {
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
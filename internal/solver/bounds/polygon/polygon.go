@@ -0,0 +1,151 @@
+// Package polygon defines a 2D bounding constraint that limits the solution
+// vector to a convex polygon.
+//
+// This is useful for e.g. vehicles with asymmetric velocity limits, where the
+// agent may move quickly forward, but only slowly in reverse or sideways. The
+// polygon need not contain the origin.
+package polygon
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/line"
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tolerance is the distance outside an edge for which a vector is still
+// considered to be within the polygon; this matches the rounding tolerance of
+// the circular and lens bounding constraints.
+const tolerance = 1e-5
+
+// M defines a 2D bounding constraint which is a convex polygon.
+type M struct {
+	// vs is the list of polygon vertices, in counter-clockwise order.
+	vs []vector.V
+}
+
+// New constructs a new convex polygon bounding constraint from the input
+// vertices, which may be in either clockwise or counter-clockwise order. New
+// returns an error if the polygon is degenerate or not strictly convex.
+func New(vs []vector.V) (*M, error) {
+	if len(vs) < 3 {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot construct a polygon with fewer than 3 vertices")
+	}
+
+	var a float64
+	for i := range vs {
+		a += vector.Determinant(vs[i], vs[(i+1)%len(vs)])
+	}
+	if epsilon.Within(a, 0) {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot construct a polygon with zero area")
+	}
+
+	us := make([]vector.V, len(vs))
+	for i, v := range vs {
+		// Ensure the vertices are ordered counter-clockwise.
+		if a < 0 {
+			i = len(vs) - 1 - i
+		}
+		us[i] = v
+	}
+
+	for i := range us {
+		d := vector.Sub(us[(i+1)%len(us)], us[i])
+		e := vector.Sub(us[(i+2)%len(us)], us[(i+1)%len(us)])
+		if vector.Determinant(d, e) <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot construct a non-convex polygon")
+		}
+	}
+
+	return &M{vs: us}, nil
+}
+
+// Bound returns the line (segment) of intersection between the polygon and the
+// input constraint.
+func (m M) Bound(c constraint.C) (segment.S, bool) {
+	l := hyperplane.Line(hyperplane.HP(c))
+
+	tmin, tmax := math.Inf(-1), math.Inf(0)
+	for i := range m.vs {
+		p, n := m.edge(i)
+
+		// The line is parallel to the edge, and lies either entirely
+		// inside or entirely outside the edge half-plane.
+		d := vector.Dot(n, l.D())
+		if epsilon.Within(d, 0) {
+			if vector.Dot(n, vector.Sub(l.P(), p)) < -tolerance {
+				return segment.S{}, false
+			}
+			continue
+		}
+
+		t := vector.Dot(n, vector.Sub(p, l.P())) / d
+		if d > 0 {
+			tmin = math.Max(tmin, t)
+		} else {
+			tmax = math.Min(tmax, t)
+		}
+	}
+
+	if tmin > tmax && !epsilon.Within(tmin, tmax) {
+		return segment.S{}, false
+	}
+	return *segment.New(l, tmin, math.Max(tmin, tmax)), true
+}
+
+// In checks if the input vector is contained within the polygon.
+func (m M) In(v vector.V) bool {
+	for i := range m.vs {
+		if p, n := m.edge(i); vector.Dot(n, vector.Sub(v, p)) < -tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// V returns the support point of the polygon in the direction of the input
+// vector, i.e. the vertex which is furthest along the input direction.
+func (m M) V(v vector.V) vector.V {
+	u := m.vs[0]
+	for _, w := range m.vs[1:] {
+		if vector.Dot(w, v) > vector.Dot(u, v) {
+			u = w
+		}
+	}
+	return u
+}
+
+// Clamp returns the point in the polygon which is closest to the input vector.
+func (m M) Clamp(v vector.V) vector.V {
+	if m.In(v) {
+		return v
+	}
+
+	var u vector.V
+	d := math.Inf(0)
+	for i := range m.vs {
+		s := *segment.New(
+			*line.New(m.vs[i], vector.Sub(m.vs[(i+1)%len(m.vs)], m.vs[i])),
+			0,
+			1,
+		)
+		w := s.L().L(s.T(v))
+		if e := vector.SquaredMagnitude(vector.Sub(v, w)); e < d {
+			u, d = w, e
+		}
+	}
+	return u
+}
+
+// edge returns a point on the i-th edge of the polygon and the unit normal of
+// the edge pointing into the polygon.
+func (m M) edge(i int) (vector.V, vector.V) {
+	d := vector.Unit(vector.Sub(m.vs[(i+1)%len(m.vs)], m.vs[i]))
+	return m.vs[i], *vector.New(-d.Y(), d.X())
+}
@@ -0,0 +1,168 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+
+	s2d "github.com/downflux/go-orca/internal/solver/2d"
+	s3d "github.com/downflux/go-orca/internal/solver/3d"
+)
+
+var (
+	_ s2d.M = M{}
+	_ s3d.M = M{}
+)
+
+// square is the counter-clockwise unit square [-1, 1] x [-1, 1].
+var square = []vector.V{
+	*vector.New(-1, -1),
+	*vector.New(1, -1),
+	*vector.New(1, 1),
+	*vector.New(-1, 1),
+}
+
+func TestNew(t *testing.T) {
+	type config struct {
+		name    string
+		vs      []vector.V
+		success bool
+	}
+
+	testConfigs := []config{
+		{name: "CCW", vs: square, success: true},
+		{
+			name: "CW",
+			vs: []vector.V{
+				*vector.New(-1, 1),
+				*vector.New(1, 1),
+				*vector.New(1, -1),
+				*vector.New(-1, -1),
+			},
+			success: true,
+		},
+		{name: "TooFew", vs: square[:2], success: false},
+		{
+			name: "Degenerate",
+			vs: []vector.V{
+				*vector.New(0, 0),
+				*vector.New(1, 0),
+				*vector.New(2, 0),
+			},
+			success: false,
+		},
+		{
+			name: "Concave",
+			vs: []vector.V{
+				*vector.New(-1, -1),
+				*vector.New(1, -1),
+				*vector.New(0, 0),
+				*vector.New(1, 1),
+				*vector.New(-1, 1),
+			},
+			success: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := New(c.vs); (err == nil) != c.success {
+				t.Errorf("New() = _, %v, want success = %v", err, c.success)
+			}
+		})
+	}
+}
+
+func TestBound(t *testing.T) {
+	m, _ := New(square)
+
+	type config struct {
+		name    string
+		c       constraint.C
+		success bool
+		want    []vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name:    "Vertical",
+			c:       *constraint.New(*vector.New(0.5, 0), *vector.New(1, 0)),
+			success: true,
+			want:    []vector.V{*vector.New(0.5, -1), *vector.New(0.5, 1)},
+		},
+		{
+			name:    "Diagonal",
+			c:       *constraint.New(*vector.New(0, 0), *vector.New(1, 1)),
+			success: true,
+			want:    []vector.V{*vector.New(-1, 1), *vector.New(1, -1)},
+		},
+		{
+			name:    "Edge",
+			c:       *constraint.New(*vector.New(1, 0), *vector.New(-1, 0)),
+			success: true,
+			want:    []vector.V{*vector.New(1, -1), *vector.New(1, 1)},
+		},
+		{
+			name:    "Outside",
+			c:       *constraint.New(*vector.New(2, 0), *vector.New(1, 0)),
+			success: false,
+		},
+		{
+			name:    "Outside/Oblique",
+			c:       *constraint.New(*vector.New(2, 2), *vector.New(1, 1)),
+			success: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			s, ok := m.Bound(c.c)
+			if ok != c.success {
+				t.Fatalf("Bound() = _, %v, want = _, %v", ok, c.success)
+			}
+			if !ok {
+				return
+			}
+			got := []vector.V{s.L().L(s.TMin()), s.L().L(s.TMax())}
+			if !(vector.Within(got[0], c.want[0]) && vector.Within(got[1], c.want[1])) && !(vector.Within(got[0], c.want[1]) && vector.Within(got[1], c.want[0])) {
+				t.Errorf("Bound() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	m, _ := New(square)
+
+	type config struct {
+		name string
+		v    vector.V
+		want vector.V
+	}
+
+	testConfigs := []config{
+		{name: "Inside", v: *vector.New(0.5, 0.5), want: *vector.New(0.5, 0.5)},
+		{name: "Edge", v: *vector.New(3, 0.5), want: *vector.New(1, 0.5)},
+		{name: "Vertex", v: *vector.New(3, 3), want: *vector.New(1, 1)},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := m.Clamp(c.v); !vector.Within(got, c.want) {
+				t.Errorf("Clamp() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestV(t *testing.T) {
+	m, _ := New(square)
+	if got, want := m.V(*vector.New(1, 2)), *vector.New(1, 1); !vector.Within(got, want) {
+		t.Errorf("V() = %v, want = %v", got, want)
+	}
+	if got := vector.Dot(m.V(*vector.New(-1, 0)), *vector.New(-1, 0)); !epsilon.Within(got, 1) {
+		t.Errorf("V() support value = %v, want = %v", got, 1)
+	}
+}
@@ -1,12 +1,17 @@
 package solver
 
 import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
 	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/2d/vector"
 	"github.com/downflux/go-orca/internal/geometry/2d/constraint"
-	"github.com/downflux/go-orca/internal/solver/bounds/circular"
 	"github.com/downflux/go-orca/internal/solver/feasibility"
 
+	c2d "github.com/downflux/go-geometry/2d/constraint"
 	s2d "github.com/downflux/go-orca/internal/solver/2d"
 	s3d "github.com/downflux/go-orca/internal/solver/3d"
 )
@@ -16,43 +21,161 @@ import (
 //
 // Note that s.T(v) finds the projected parametric t-value of the underlying
 // line, bounded by the min / max values of the line segment.
+func project(s segment.S, v vector.V) vector.V {
+	return s.L().L(s.T(v))
+}
+
+// objective returns the optimization function passed into the 2D solver, which
+// finds the point on a constraint segment closest to the (biased) preferred
+// vector v.
 //
-// Adding a noise function here to the output will randomize the path of the
-// agent a bit, e.g.
+// Two slow-moving agents may become stuck while trying to move directly past
+// each other (i.e. agent targets are directly behind the opposing agent), as
+// the symmetric ORCA constraints offer neither agent a reason to pick a side.
+// We break the symmetry by
 //
-//	weight := 1e-3
-//	dt := rand.Float64() * (s.TMax() - s.TMin()) * weight
-//	v := s.L().L(s.T(v) + dt)
+//  1. shifting the target of the projection to the right of v (or to the left,
+//     for negative biases), so that constrained agents consistently pass each
+//     other on the same side, as in a traffic rule; and
 //
-// This will help with the case where two slow-moving agents become stuck while
-// trying to move directly past each other (i.e. agent targets are directly
-// behind the opposing agent).
+//  2. optionally adding seeded noise to the projected t-value, i.e.
 //
-// This may be feasible, but other solutions to the problem exist, e.g. in a
-// tile-based map, set the actual target to some randomized value around the
-// input target, and re-adjust the target once an agent is inside the correct
-// tile.
-func project(s segment.S, v vector.V) vector.V {
-	return s.L().L(s.T(v))
+//     dt := (2 * rand.Float64() - 1) * (s.TMax() - s.TMin()) * o.Jitter
+//
+// Note that the bias only affects the solution if v is infeasible; an
+// unconstrained agent still travels at its preferred velocity.
+func objective(v vector.V, o O, r *rand.Rand) s2d.O {
+	w := vector.Add(v, vector.Scale(o.Bias, *vector.New(v.Y(), -v.X())))
+	return func(s segment.S) vector.V {
+		t := s.T(w)
+		if l := s.TMax() - s.TMin(); o.Jitter > 0 && !math.IsInf(l, 0) {
+			t = math.Max(s.TMin(), math.Min(s.TMax(), t+(2*r.Float64()-1)*l*o.Jitter))
+		}
+		return s.L().L(t)
+	}
+}
+
+// M is a bounding constraint for the solution vector, e.g. the maximum speed
+// of an agent.
+type M interface {
+	s3d.M
+
+	// Clamp returns the vector within the bounds of M which is closest to
+	// the input vector.
+	Clamp(v vector.V) vector.V
+}
+
+// O specifies optional settings for the solver.
+type O struct {
+	// Shuffle randomizes the order in which constraints are added to the
+	// incremental solver.
+	//
+	// Per de Berg et al. (2008), the incremental 2D LP algorithm runs in
+	// expected O(n) time for a random permutation of the input
+	// constraints, but may take O(n²) time for adversarial orderings, e.g.
+	// a set of successively tighter constraints, each of which invalidates
+	// the previous solution.
+	//
+	// N.B.: The 3D fallback is not order-invariant; immutable constraints
+	// (e.g. walls) are therefore always processed ahead of all mutable
+	// constraints, and only shuffled amongst themselves.
+	Shuffle bool
+
+	// Bias is the passing-side preference of the solver, as a fraction of
+	// the preferred speed. When the preferred vector is infeasible, the
+	// solver prefers solutions to the right of the preferred vector for
+	// positive values, and to the left for negative values.
+	Bias float64
+
+	// Jitter is the magnitude of the noise added to the solution, as a
+	// fraction of the length of the constraint segment on which the
+	// solution lies.
+	Jitter float64
+
+	// Seed seeds the constraint shuffle and jitter. The output of Solve is
+	// deterministic for a given seed.
+	Seed int64
+
+	// Warm is an optional warm start for the solver, e.g. the solution of
+	// the same agent in the previous simulation tick.
+	//
+	// Frame to frame, the set of constraints which bind the solution
+	// rarely changes. We therefore first solve the small LP consisting of
+	// only the constraints which are active at the warm start vector (i.e.
+	// violated by or nearly tight at the vector). This LP is a relaxation
+	// of the full system, so if its solution satisfies all remaining
+	// constraints, it is also the solution of the full system, and the
+	// solver only needs a single feasibility pass over the remaining
+	// constraints. Otherwise, the solver falls back to the full
+	// incremental solve, exactly as if no warm start was given. The
+	// relaxed system is skipped if every constraint is active at the warm
+	// start vector.
+	//
+	// Infeasible systems therefore always fall back to the full solve, and
+	// are unaffected by the warm start. The solution of a feasible system
+	// is also unaffected, unless Jitter is set, in which case the noise
+	// added to the solution of the relaxed system may differ from the
+	// noise added to the solution of the full system.
+	//
+	// N.B.: The warm start only speeds up the LP solve itself. In a full
+	// simulation tick, the LP is typically a small fraction of the cost,
+	// which is dominated by the neighbor queries and VO construction; see
+	// BenchmarkWarm in examples/simulation.
+	Warm *vector.V
+
+	// Robust uses exact geometric predicates in the 2D solver, which
+	// ensures nearly parallel or nearly tight constraints are classified
+	// consistently. See internal/geometry/2d/predicate for more details.
+	//
+	// N.B.: The 3D fallback for infeasible systems always uses the
+	// default predicates. The fallback is a heuristic whose output is
+	// sensitive to rounding errors in the partial 2D solution, and exact
+	// predicates do not make its output any more consistent.
+	Robust bool
 }
 
 // Solve attempts to find a vector which satisfies all constraints and minimizes
-// the distance to the input preferred vector v, with maximum length of v set to
-// r.
-func Solve(cs []constraint.C, v vector.V, r float64) vector.V {
-	m := *circular.New(r)
+// the distance to the input preferred vector v, where the solution is bounded
+// by m, e.g. a circular.M for an agent with a maximum speed.
+func Solve(m M, cs []constraint.C, v vector.V, o O) vector.V {
+	if o.Shuffle {
+		cs = shuffle(cs, rand.New(rand.NewSource(o.Seed)))
+	}
+
 	// Ensure the desired target velocity is within the initial bounding
 	// constraints.
-	if !m.In(v) {
-		v = m.V(v)
+	v = m.Clamp(v)
+
+	// The jitter is drawn from a separate source, which is reset for each
+	// solve, so that the jitter drawn by the full solve does not depend
+	// on whether the warm start was attempted.
+	g := func() s2d.O {
+		var r *rand.Rand
+		if o.Jitter > 0 {
+			r = rand.New(rand.NewSource(o.Seed))
+		}
+		return objective(v, o, r)
+	}
+	var solve lp = s2d.Solve
+	if o.Robust {
+		solve = s2d.SolveRobust
+	}
+
+	if o.Warm != nil {
+		// If every constraint is active at the warm start vector, the
+		// relaxed system is the full system, and there is nothing to
+		// gain from solving it separately.
+		if ws, n := warm(cs, *o.Warm); n < len(ws) {
+			if u, ok := seed(m, ws, n, g(), v, solve); ok {
+				return u
+			}
+		}
 	}
 
-	u, f := s2d.Solve(m, cs, func(s segment.S) vector.V {
-		return project(s, v)
-	}, v)
+	u, f := solve(m, cs, g(), v)
 
 	if f == feasibility.Partial {
-		u, f = s3d.Solve(m, cs, u)
+		u, f = relax(m, cs, g(), v, u, solve)
 	}
 	if f != feasibility.Feasible {
 		panic("cannot solve linear programming problem for the given set of ORCA lines")
@@ -60,3 +183,151 @@ func Solve(cs []constraint.C, v vector.V, r float64) vector.V {
 
 	return u
 }
+
+// shuffle returns a random permutation of the input constraints, where all
+// immutable constraints precede the mutable constraints. The input slice is not
+// modified.
+func shuffle(cs []constraint.C, r *rand.Rand) []constraint.C {
+	ds := make([]constraint.C, 0, len(cs))
+	for _, c := range cs {
+		if !c.Mutable() {
+			ds = append(ds, c)
+		}
+	}
+	n := len(ds)
+	for _, c := range cs {
+		if c.Mutable() {
+			ds = append(ds, c)
+		}
+	}
+
+	for _, es := range [][]constraint.C{ds[:n], ds[n:]} {
+		r.Shuffle(len(es), func(i, j int) { es[i], es[j] = es[j], es[i] })
+	}
+	return ds
+}
+
+// margin is the distance, as a fraction of the magnitude of the warm start
+// vector, within which a constraint is considered to be nearly tight at the
+// warm start vector.
+const margin = 1e-2
+
+// warm returns a stable reordering of the input constraints, where all
+// immutable constraints come first, followed by the mutable constraints which
+// are active at the warm start vector w, followed by the remaining mutable
+// constraints. warm additionally returns the number of constraints which
+// precede the inactive mutable constraints. The input slice is not modified.
+func warm(cs []constraint.C, w vector.V) ([]constraint.C, int) {
+	d := margin * math.Max(1, vector.Magnitude(w))
+
+	var as, bs []constraint.C
+	ds := make([]constraint.C, 0, len(cs))
+	for _, c := range cs {
+		switch {
+		case !c.Mutable():
+			ds = append(ds, c)
+		case !c.In(w) || hyperplane.Line(hyperplane.HP(c.C())).Distance(w) < d:
+			as = append(as, c)
+		default:
+			bs = append(bs, c)
+		}
+	}
+	ds = append(ds, as...)
+	return append(ds, bs...), len(ds)
+}
+
+// seed solves the relaxed system consisting of the first n constraints of the
+// input, i.e. the immutable constraints and the constraints active at the warm
+// start vector. If the solution of the relaxed system satisfies the remaining
+// constraints, it is also the solution of the full system, and seed returns
+// the solution; otherwise, seed returns false.
+func seed(m M, cs []constraint.C, n int, g s2d.O, v vector.V, solve lp) (vector.V, bool) {
+	u, f := solve(m, cs[:n], g, v)
+	if f != feasibility.Feasible {
+		return vector.V{}, false
+	}
+	for _, c := range cs[n:] {
+		if !c.In(u) {
+			return vector.V{}, false
+		}
+	}
+	return u, true
+}
+
+// lp is a 2D solver, e.g. s2d.Solve.
+type lp func(m s2d.M, cs []constraint.C, o s2d.O, v vector.V) (vector.V, feasibility.F)
+
+// tolerance is the additional distance by which relaxed constraints are
+// loosened to guard against rounding errors.
+const tolerance = 1e-9
+
+// relax finds a solution to an infeasible system of constraints, given the
+// partial 2D solution u, and the optimization function g and implementation
+// solve of the 2D solver.
+//
+// If all mutable constraints are in the same priority tier, this is just the
+// 3D slack-variable solver. Otherwise, we relax the constraints tier by tier,
+// lexicographically --
+//
+//  1. starting from the highest tier, we solve the system consisting of the
+//     immutable constraints and the constraints in the current tier, falling
+//     back to the 3D solver if necessary;
+//  2. we then loosen every constraint in the current tier by the maximum
+//     penetration distance of this solution into the tier, which ensures the
+//     loosened tier is feasible, and treat the loosened constraints as
+//     immutable for all subsequent tiers; and
+//  3. the solution of the lowest tier is the final solution.
+//
+// Thus a constraint in a lower tier is only satisfied if doing so does not
+// require any additional violation of a higher-tier constraint.
+func relax(m M, cs []constraint.C, g s2d.O, v vector.V, u vector.V, solve lp) (vector.V, feasibility.F) {
+	var ts []int
+	tiers := map[int][]constraint.C{}
+	var hs []constraint.C
+	for _, c := range cs {
+		if !c.Mutable() {
+			hs = append(hs, c)
+			continue
+		}
+		if _, ok := tiers[c.Tier()]; !ok {
+			ts = append(ts, c.Tier())
+		}
+		tiers[c.Tier()] = append(tiers[c.Tier()], c)
+	}
+	if len(ts) <= 1 {
+		return s3d.Solve(m, cs, u)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(ts)))
+
+	f := feasibility.Feasible
+	for i, t := range ts {
+		ds := append(append(make([]constraint.C, 0, len(hs)+len(tiers[t])), hs...), tiers[t]...)
+
+		u, f = solve(m, ds, g, v)
+		if f == feasibility.Partial {
+			u, f = s3d.Solve(m, ds, u)
+		}
+		if f != feasibility.Feasible || i == len(ts)-1 {
+			break
+		}
+
+		var d float64
+		for _, c := range tiers[t] {
+			if !c.In(u) {
+				d = math.Max(d, hyperplane.Line(hyperplane.HP(c.C())).Distance(u))
+			}
+		}
+		for _, c := range tiers[t] {
+			hp := hyperplane.HP(c.C())
+			if d > 0 {
+				hp = *hyperplane.New(
+					vector.Sub(hp.P(), vector.Scale(d+tolerance, vector.Unit(hp.N()))),
+					hp.N(),
+				)
+			}
+			hs = append(hs, *constraint.New(c2d.C(hp), false))
+		}
+	}
+	return u, f
+}
@@ -1,19 +1,32 @@
 package solver
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
 	"github.com/downflux/go-orca/internal/geometry/2d/constraint"
+	"github.com/downflux/go-orca/internal/solver/bounds/circular"
+	"github.com/downflux/go-orca/internal/solver/bounds/lens"
+	"github.com/downflux/go-orca/internal/solver/bounds/unbounded"
 
 	c2d "github.com/downflux/go-geometry/2d/constraint"
 	v2d "github.com/downflux/go-geometry/2d/vector"
 	s2d "github.com/downflux/go-orca/internal/solver/2d"
 )
 
-var _ s2d.O = func(s segment.S) vector.V { return project(s, v2d.V{}) }
+var (
+	_ s2d.O = func(s segment.S) vector.V { return project(s, v2d.V{}) }
+
+	_ M = circular.M{}
+	_ M = lens.M{}
+	_ M = unbounded.M{}
+)
 
 func TestSolve(t *testing.T) {
 	type config struct {
@@ -156,9 +169,335 @@ func TestSolve(t *testing.T) {
 
 	for _, c := range testConfigs {
 		t.Run(c.name, func(t *testing.T) {
-			if got := Solve(c.cs, c.v, c.r); !v2d.Within(c.want, got) {
+			if got := Solve(*circular.New(c.r), c.cs, c.v, O{}); !v2d.Within(c.want, got) {
 				t.Errorf("Solve() = %v, want = %v", got, c.want)
 			}
 		})
 	}
 }
+
+func TestShuffle(t *testing.T) {
+	const n = 100
+
+	r := rand.New(rand.NewSource(0))
+
+	cs := make([]constraint.C, 0, n)
+	for i := 0; i < n; i++ {
+		cs = append(cs, *constraint.New(
+			*c2d.New(
+				*v2d.New(r.Float64()-0.5, r.Float64()-0.5),
+				*v2d.New(r.Float64()-0.5, r.Float64()-0.5),
+			),
+			i%3 != 0,
+		))
+	}
+	ds := make([]constraint.C, len(cs))
+	copy(ds, cs)
+
+	got := shuffle(cs, rand.New(rand.NewSource(1)))
+
+	t.Run("Unmodified", func(t *testing.T) {
+		for i := range cs {
+			if !reflect.DeepEqual(cs[i], ds[i]) {
+				t.Fatalf("shuffle() modified the input slice at index %v", i)
+			}
+		}
+	})
+	t.Run("Deterministic", func(t *testing.T) {
+		want := shuffle(cs, rand.New(rand.NewSource(1)))
+		for i := range got {
+			if !reflect.DeepEqual(got[i], want[i]) {
+				t.Fatalf("shuffle()[%v] = %v, want = %v", i, got[i], want[i])
+			}
+		}
+	})
+	t.Run("Immutable", func(t *testing.T) {
+		for i := 1; i < len(got); i++ {
+			if !got[i].Mutable() && got[i-1].Mutable() {
+				t.Fatalf("shuffle()[%v] is an immutable constraint which follows a mutable constraint", i)
+			}
+		}
+	})
+}
+
+// TestSolveShuffle checks that the solution to a feasible 2D system does not
+// depend on the order in which constraints are processed.
+func TestSolveShuffle(t *testing.T) {
+	const n = 100
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		// All constraints contain the origin, so the system is always
+		// feasible.
+		var cs []constraint.C
+		for j := 0; j < 20; j++ {
+			p := *v2d.New(r.Float64()*10-5, r.Float64()*10-5)
+			cs = append(cs, *constraint.New(*c2d.New(p, v2d.Scale(-1, p)), true))
+		}
+		v := *v2d.New(r.Float64()*20-10, r.Float64()*20-10)
+
+		t.Run(fmt.Sprintf("Random-%v", i), func(t *testing.T) {
+			want := Solve(*circular.New(10), cs, v, O{})
+			if got := Solve(*circular.New(10), cs, v, O{Shuffle: true, Seed: int64(i)}); !v2d.WithinEpsilon(got, want, epsilon.Absolute(1e-6)) {
+				t.Errorf("Solve() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWarm(t *testing.T) {
+	// a is active at the warm start vector, b is nearly tight, c is
+	// slack, and d is an immutable constraint.
+	a := *constraint.New(*c2d.New(*v2d.New(0, 1), *v2d.New(0, 1)), true)
+	b := *constraint.New(*c2d.New(*v2d.New(1e-3, 0), *v2d.New(-1, 0)), true)
+	c := *constraint.New(*c2d.New(*v2d.New(0, -5), *v2d.New(0, 1)), true)
+	d := *constraint.New(*c2d.New(*v2d.New(0, -10), *v2d.New(0, 1)), false)
+
+	cs := []constraint.C{c, b, d, a}
+	got, n := warm(cs, *v2d.New(0, 0))
+
+	want := []constraint.C{d, b, a, c}
+	if !reflect.DeepEqual(got, want) || n != 3 {
+		t.Errorf("warm() = %v, %v, want = %v, %v", got, n, want, 3)
+	}
+	if !reflect.DeepEqual(cs, []constraint.C{c, b, d, a}) {
+		t.Errorf("warm() modified the input slice")
+	}
+}
+
+// TestSolveWarm checks that the solution to a feasible 2D system does not
+// depend on the warm start vector, including when the warm start vector is the
+// solution itself, i.e. when the solution of the relaxed system is returned.
+func TestSolveWarm(t *testing.T) {
+	const n = 100
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		var cs []constraint.C
+		for j := 0; j < 20; j++ {
+			p := *v2d.New(r.Float64()*10-5, r.Float64()*10-5)
+			cs = append(cs, *constraint.New(*c2d.New(p, v2d.Scale(-1, p)), true))
+		}
+		v := *v2d.New(r.Float64()*20-10, r.Float64()*20-10)
+		w := *v2d.New(r.Float64()*20-10, r.Float64()*20-10)
+
+		t.Run(fmt.Sprintf("Random-%v", i), func(t *testing.T) {
+			want := Solve(*circular.New(10), cs, v, O{})
+			for _, w := range []v2d.V{w, want} {
+				if got := Solve(*circular.New(10), cs, v, O{Warm: &w}); !v2d.WithinEpsilon(got, want, epsilon.Absolute(1e-6)) {
+					t.Errorf("Solve() = %v, want = %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestSolveWarmJitter checks that the warm start does not change the jittered
+// solution when the relaxed system does not solve the full system, i.e. the
+// warm start does not consume the jitter drawn by the full solve.
+func TestSolveWarmJitter(t *testing.T) {
+	// a is the half-plane x ≥ 0.5, which is active at the warm start
+	// vector, and b is the half-plane y ≤ 1, which is not, but which is
+	// violated by the solution of the relaxed system.
+	a := *constraint.New(*c2d.New(*v2d.New(0.5, 0), *v2d.New(1, 0)), true)
+	b := *constraint.New(*c2d.New(*v2d.New(0, 1), *v2d.New(0, -1)), true)
+
+	cs := []constraint.C{b, a}
+	v := *v2d.New(0, 5)
+	w := *v2d.New(0, 0)
+
+	for i := int64(0); i < 10; i++ {
+		t.Run(fmt.Sprintf("Seed=%v", i), func(t *testing.T) {
+			want := Solve(*circular.New(10), cs, v, O{Jitter: 0.1, Seed: i})
+			if got := Solve(*circular.New(10), cs, v, O{Jitter: 0.1, Seed: i, Warm: &w}); !v2d.Within(got, want) {
+				t.Errorf("Solve() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+// TestSolveRobust checks that the robust predicates do not change the solution
+// of non-degenerate feasible systems.
+func TestSolveRobust(t *testing.T) {
+	const n = 100
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < n; i++ {
+		// All constraints contain the origin, so the system is always
+		// feasible.
+		var cs []constraint.C
+		for j := 0; j < 20; j++ {
+			p := *v2d.New(r.Float64()*10-5, r.Float64()*10-5)
+			cs = append(cs, *constraint.New(*c2d.New(p, v2d.Scale(-1, p)), true))
+		}
+		v := *v2d.New(r.Float64()*20-10, r.Float64()*20-10)
+
+		t.Run(fmt.Sprintf("Random-%v", i), func(t *testing.T) {
+			want := Solve(*circular.New(10), cs, v, O{})
+			if got := Solve(*circular.New(10), cs, v, O{Robust: true}); !v2d.WithinEpsilon(got, want, epsilon.Absolute(1e-6)) {
+				t.Errorf("Solve() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+// BenchmarkSolve compares the solver performance over an adversarial ordering
+// of constraints, with and without shuffling and warm starts. Each constraint in the
+// adversarial ordering is strictly tighter than all previous constraints, and
+// therefore invalidates the previous solution.
+func BenchmarkSolve(b *testing.B) {
+	type config struct {
+		name string
+		cs   []constraint.C
+		o    O
+	}
+
+	var testConfigs []config
+	for n := 10; n <= 1000; n *= 10 {
+		cs := make([]constraint.C, 0, n)
+		for i := 0; i < n; i++ {
+			// Alternate the slope of each constraint slightly to
+			// avoid parallel constraints.
+			cs = append(cs, *constraint.New(
+				*c2d.New(
+					*v2d.New(0, 1-float64(i)/float64(n)),
+					*v2d.New(math.Pow(-1, float64(i))*1e-3, -1),
+				),
+				true,
+			))
+		}
+
+		// Warm start the solver with the true solution, as would be
+		// the case for a static scene.
+		w := Solve(*circular.New(10), cs, *v2d.New(0, 5), O{})
+		for _, o := range []O{{}, {Shuffle: true}, {Warm: &w}} {
+			testConfigs = append(testConfigs, config{
+				name: fmt.Sprintf("Shuffle=%v/Warm=%v/N=%v", o.Shuffle, o.Warm != nil, n),
+				cs:   cs,
+				o:    o,
+			})
+		}
+	}
+
+	for _, c := range testConfigs {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Solve(*circular.New(10), c.cs, *v2d.New(0, 5), c.o)
+			}
+		})
+	}
+}
+
+func TestSolveTier(t *testing.T) {
+	// a, b, and c are the half-planes y ≥ 1, y ≤ -1, and x ≥ 2
+	// respectively; a and b are mutually exclusive.
+	a := *c2d.New(*v2d.New(0, 1), *v2d.New(0, 1))
+	b := *c2d.New(*v2d.New(0, -1), *v2d.New(0, -1))
+	c := *c2d.New(*v2d.New(2, 0), *v2d.New(1, 0))
+
+	type config struct {
+		name string
+		cs   []constraint.C
+
+		// in is the list of constraints which the solution must
+		// satisfy.
+		in []c2d.C
+	}
+
+	testConfigs := []config{
+		{
+			name: "High",
+			cs:   []constraint.C{*constraint.NewTier(a, 1), *constraint.NewTier(b, 0)},
+			in:   []c2d.C{a},
+		},
+		{
+			name: "Low",
+			cs:   []constraint.C{*constraint.NewTier(a, 0), *constraint.NewTier(b, 1)},
+			in:   []c2d.C{b},
+		},
+		{
+			name: "Order",
+			cs:   []constraint.C{*constraint.NewTier(b, 0), *constraint.NewTier(a, 1)},
+			in:   []c2d.C{a},
+		},
+		{
+			name: "Immutable",
+			cs:   []constraint.C{*constraint.NewTier(b, 1), *constraint.New(a, false)},
+			in:   []c2d.C{a},
+		},
+		{
+			name: "Lexicographic",
+			cs:   []constraint.C{*constraint.NewTier(a, 2), *constraint.NewTier(c, 1), *constraint.NewTier(b, 0)},
+			in:   []c2d.C{a, c},
+		},
+		// The top tier is infeasible by itself; the relaxed top tier
+		// should not prevent the bottom tier from being satisfied.
+		{
+			name: "Relaxed",
+			cs:   []constraint.C{*constraint.NewTier(a, 1), *constraint.NewTier(b, 1), *constraint.NewTier(c, 0)},
+			in:   []c2d.C{c},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got := Solve(*circular.New(10), c.cs, *v2d.New(0, 0), O{})
+			for _, d := range c.in {
+				if !d.In(got) {
+					t.Errorf("Solve() = %v, which does not satisfy the constraint %v", got, d)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveBias(t *testing.T) {
+	// c is the half-plane y ≤ 1, which lies directly in front of the
+	// preferred vector.
+	c := *constraint.New(*c2d.New(*v2d.New(0, 1), *v2d.New(0, -1)), true)
+	v := *v2d.New(0, 2)
+
+	type config struct {
+		name string
+		o    O
+		want v2d.V
+	}
+
+	testConfigs := []config{
+		{name: "None", o: O{}, want: *v2d.New(0, 1)},
+		{name: "Right", o: O{Bias: 0.5}, want: *v2d.New(1, 1)},
+		{name: "Left", o: O{Bias: -0.5}, want: *v2d.New(-1, 1)},
+	}
+
+	for _, d := range testConfigs {
+		t.Run(d.name, func(t *testing.T) {
+			if got := Solve(*circular.New(10), []constraint.C{c}, v, d.o); !v2d.Within(got, d.want) {
+				t.Errorf("Solve() = %v, want = %v", got, d.want)
+			}
+		})
+	}
+
+	t.Run("Unconstrained", func(t *testing.T) {
+		if got := Solve(*circular.New(10), nil, v, O{Bias: 0.5}); !v2d.Within(got, v) {
+			t.Errorf("Solve() = %v, want = %v", got, v)
+		}
+	})
+}
+
+func TestSolveJitter(t *testing.T) {
+	c := *constraint.New(*c2d.New(*v2d.New(0, 1), *v2d.New(0, -1)), true)
+	v := *v2d.New(0, 2)
+
+	o := O{Jitter: 0.1, Seed: 1}
+	got := Solve(*circular.New(10), []constraint.C{c}, v, o)
+
+	if want := Solve(*circular.New(10), []constraint.C{c}, v, o); !v2d.Within(got, want) {
+		t.Errorf("Solve() = %v, want = %v", got, want)
+	}
+	if !epsilon.Within(got.Y(), 1) {
+		t.Errorf("Solve() = %v, which does not lie on the constraint", got)
+	}
+	if d := math.Abs(got.X()); d > 0.1*2*math.Sqrt(99) {
+		t.Errorf("Solve() = %v, which exceeds the maximum jitter", got)
+	}
+}
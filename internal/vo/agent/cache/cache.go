@@ -27,6 +27,7 @@ import (
 	"github.com/downflux/go-geometry/2d/vector"
 	"github.com/downflux/go-geometry/epsilon"
 	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/uncertainty"
 	"github.com/downflux/go-orca/internal/geometry/2d/cone"
 	"github.com/downflux/go-orca/internal/vo/agent/cache/domain"
 	"github.com/downflux/go-orca/internal/vo/agent/opt"
@@ -106,7 +107,7 @@ func New(o O) (*VO, error) {
 	}
 
 	// c defines the truncation circle.
-	c := *hypersphere.New(vector.Scale(1/o.Tau, p(o.Agent, o.Obstacle)), r(o.Agent, o.Obstacle)/o.Tau)
+	c := *hypersphere.New(vector.Scale(1/o.Tau, p(o.Agent, o.Obstacle)), r(o.Agent, o.Obstacle, o.Tau)/o.Tau)
 
 	// We cannot construct a valid cone if the two agents are overlapping,
 	// i.e. in the collision domain.
@@ -149,12 +150,12 @@ func (vo *VO) preprocess() (result, error) {
 	case domain.Collision:
 		fallthrough
 	case domain.Circle:
-		tr := vo.r()
+		tr := vo.r() / vo.tau
 		tw := vo.w()
 
 		if d == domain.Collision {
-			tr = r(vo.agent, vo.obstacle) / minTau
-			tw = w(vo.agent, vo.obstacle, minTau)
+			tr = r(vo.agent, vo.obstacle, minTau) / minTau
+			tw = vector.Sub(vo.v(), vector.Scale(1/minTau, vo.p()))
 		}
 
 		u := vector.Scale(tr/vector.Magnitude(tw)-1, tw)
@@ -275,7 +276,7 @@ func (vo *VO) u() (vector.V, error) {
 func (vo *VO) r() float64 {
 	if !vo.rIsCached {
 		vo.rIsCached = true
-		vo.rCache = r(vo.agent, vo.obstacle)
+		vo.rCache = r(vo.agent, vo.obstacle, vo.tau)
 	}
 	return vo.rCache
 }
@@ -314,21 +315,28 @@ func (vo *VO) p() vector.V {
 	return vo.pCache
 }
 
-// v calculates the relative velocity between a and b.
+// v calculates the relative optimization velocity between a and b.
+//
+// Per van den Berg et al. (2011), u is measured from the relative optimization
+// velocity, not the relative current velocity; this ensures the ORCA plane,
+// which is anchored at the optimization velocity of the agent, actually
+// contains the optimization velocity when the optimization velocities of the
+// two agents do not collide. When the optimization velocity is the current
+// velocity, this is the usual relative velocity.
 func (vo *VO) v() vector.V {
 	if !vo.vIsCached {
 		vo.vIsCached = true
-		vo.vCache = v(vo.agent, vo.obstacle)
+		vo.vCache = vector.Sub(vo.vopt(vo.agent), vo.vopt(vo.obstacle))
 	}
 	return vo.vCache
 }
 
-// w calculates the relative velocity between the agent and obstacle, centered
-// on the truncation circle.
+// w calculates the relative optimization velocity between the agent and
+// obstacle, centered on the truncation circle.
 func (vo *VO) w() vector.V {
 	if !vo.wIsCached {
 		vo.wIsCached = true
-		vo.wCache = w(vo.agent, vo.obstacle, vo.tau)
+		vo.wCache = vector.Sub(vo.v(), vector.Scale(1/vo.tau, vo.p()))
 	}
 	return vo.wCache
 }
@@ -419,7 +427,12 @@ func (vo *VO) theta() (float64, error) {
 func v(a agent.A, b agent.A) vector.V { return vector.Sub(a.V(), b.V()) }
 
 // r is a utility function calculating the radius of the untruncated VO circle.
-func r(a agent.A, b agent.A) float64 { return a.R() + b.R() }
+//
+// The radius is enlarged by the state estimation error of the agents over the
+// lookahead time 𝜏, if set; see uncertainty.A for more details.
+func r(a agent.A, b agent.A, tau float64) float64 {
+	return a.R() + b.R() + uncertainty.R(a, b, tau)
+}
 
 // p is a utility function calculating the relative position vector between two
 // agents. p is in position space, and as such, is not directly scaled by the
@@ -1,11 +1,21 @@
 package cache
 
 import (
+	"fmt"
+	"math"
+	"math/rand"
 	"testing"
 
+	"github.com/downflux/go-geometry/2d/hyperplane"
 	"github.com/downflux/go-geometry/2d/vector"
 	"github.com/downflux/go-geometry/epsilon"
 	"github.com/downflux/go-orca/internal/agent"
+	"github.com/downflux/go-orca/internal/vo/agent/cache/domain"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/internal/vo/conformance"
+
+	ragent "github.com/downflux/go-orca/agent"
+	reference "github.com/downflux/go-orca/external/snape/RVO2/vo/agent/cache"
 )
 
 func TestOrientation(t *testing.T) {
@@ -23,10 +33,10 @@ func TestOrientation(t *testing.T) {
 	})
 	t.Run("R", func(t *testing.T) {
 		want := 3.0
-		if got := r(a, b); !epsilon.Within(got, want) {
+		if got := r(a, b, 1); !epsilon.Within(got, want) {
 			t.Errorf("r() = %v, want = %v", got, want)
 		}
-		if got := r(b, a); !epsilon.Within(got, want) {
+		if got := r(b, a, 1); !epsilon.Within(got, want) {
 			t.Errorf("r() = %v, want = %v", got, want)
 		}
 	})
@@ -49,3 +59,327 @@ func TestOrientation(t *testing.T) {
 		}
 	})
 }
+
+// u is an agent with bounded state estimation error.
+type u struct {
+	agent.A
+	ep float64
+	ev float64
+}
+
+func (u u) EP() float64 { return u.ep }
+func (u u) EV() float64 { return u.ev }
+
+func TestR(t *testing.T) {
+	a := *agent.New(agent.O{P: *vector.New(0, 0), V: *vector.New(0, 0), R: 1})
+	b := *agent.New(agent.O{P: *vector.New(0, 5), V: *vector.New(1, -1), R: 2})
+
+	type config struct {
+		name     string
+		agent    ragent.A
+		obstacle ragent.A
+		tau      float64
+		want     float64
+	}
+
+	testConfigs := []config{
+		{
+			name:     "Exact",
+			agent:    a,
+			obstacle: b,
+			tau:      2,
+			want:     3,
+		},
+		{
+			name:     "Position",
+			agent:    u{A: a, ep: 0.5},
+			obstacle: u{A: b, ep: 0.25},
+			tau:      2,
+			want:     3.75,
+		},
+		{
+			name:     "Velocity",
+			agent:    u{A: a, ev: 0.5},
+			obstacle: b,
+			tau:      2,
+			want:     4,
+		},
+		{
+			name:     "Mixed",
+			agent:    u{A: a, ep: 0.5, ev: 0.5},
+			obstacle: u{A: b, ep: 0.5, ev: 0.5},
+			tau:      2,
+			want:     6,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := r(c.agent, c.obstacle, c.tau); !epsilon.Within(got, c.want) {
+				t.Errorf("r() = %v, want = %v", got, c.want)
+			}
+			if got := r(c.obstacle, c.agent, c.tau); !epsilon.Within(got, c.want) {
+				t.Errorf("r() = %v, want = %v", got, c.want)
+			}
+
+			vo, err := New(O{
+				Agent:    c.agent,
+				Obstacle: c.obstacle,
+				Tau:      c.tau,
+				Weight:   opt.WeightEqual,
+				VOpt:     opt.VOptV,
+			})
+			if err != nil {
+				t.Fatalf("New() returned an unexpected error: %v", err)
+			}
+			if got, want := vo.base.R(), c.want/c.tau; !epsilon.Within(got, want) {
+				t.Errorf("base.R() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+// TestVOptZero checks that the zero vector is always feasible for an agent
+// which does not physically collide with the obstacle when both agents choose
+// the zero vector as their optimization velocity, regardless of the current
+// velocities of the agents.
+func TestVOptZero(t *testing.T) {
+	const n = 1000
+
+	type config struct {
+		name     string
+		agent    agent.A
+		obstacle agent.A
+		tau      float64
+	}
+
+	testConfigs := []config{
+		{
+			name:     "Manual/HeadOn",
+			agent:    *agent.New(agent.O{P: *vector.New(0, 0), V: *vector.New(1, 0), R: 1}),
+			obstacle: *agent.New(agent.O{P: *vector.New(3, 0), V: *vector.New(-1, 0), R: 1}),
+			tau:      1,
+		},
+	}
+
+	for i := 0; i < n; i++ {
+		a := *agent.New(agent.O{
+			P: *vector.New(rand.Float64()*20-10, rand.Float64()*20-10),
+			V: *vector.New(rand.Float64()*20-10, rand.Float64()*20-10),
+			R: rand.Float64() * 5,
+		})
+		b := *agent.New(agent.O{
+			P: *vector.New(rand.Float64()*20-10, rand.Float64()*20-10),
+			V: *vector.New(rand.Float64()*20-10, rand.Float64()*20-10),
+			R: rand.Float64() * 5,
+		})
+		// Skip agents which physically collide, as the zero vector
+		// lies within the VO.
+		if vector.Magnitude(p(a, b)) <= a.R()+b.R() {
+			continue
+		}
+		testConfigs = append(testConfigs, config{
+			name:     fmt.Sprintf("Random/%v", i),
+			agent:    a,
+			obstacle: b,
+			tau:      rand.Float64()*10 + minTau,
+		})
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			vo, err := New(O{
+				Agent:    c.agent,
+				Obstacle: c.obstacle,
+				Tau:      c.tau,
+				Weight:   opt.WeightEqual,
+				VOpt:     opt.VOptZero,
+			})
+			if err != nil {
+				t.Fatalf("New() returned an unexpected error: %v", err)
+			}
+			got, err := vo.ORCA()
+			if err != nil {
+				t.Fatalf("ORCA() returned an unexpected error: %v", err)
+			}
+			if v := *vector.New(0, 0); !got.In(v) {
+				t.Errorf("ORCA().In(%v) = false, want = true", v)
+			}
+		})
+	}
+}
+
+// TestConformance is a randomized differential test which checks the ORCA
+// plane of the VO against the RVO2 reference implementation across all
+// domains.
+//
+// On failure, the mismatching input is minimized and printed as a config
+// literal which may be directly added to the manual test cases.
+func TestConformance(t *testing.T) {
+	const (
+		n   = 1000
+		tol = 1e-5
+	)
+
+	type config struct {
+		name     string
+		agent    agent.A
+		obstacle agent.A
+		tau      float64
+	}
+
+	testConfigs := []config{
+		{
+			name:     "Manual/Circle",
+			agent:    *agent.New(agent.O{P: *vector.New(0, 0), V: *vector.New(0, 4), R: 1}),
+			obstacle: *agent.New(agent.O{P: *vector.New(0, 5), V: *vector.New(0, 0), R: 2}),
+			tau:      1,
+		},
+		{
+			name:     "Manual/Collision",
+			agent:    *agent.New(agent.O{P: *vector.New(0, 0), V: *vector.New(0, 0), R: 1}),
+			obstacle: *agent.New(agent.O{P: *vector.New(0, 2), V: *vector.New(1, -1), R: 2}),
+			tau:      1,
+		},
+	}
+
+	// encode and decode convert between a config and the free parameters
+	// manipulated by conformance.Minimize.
+	encode := func(c config) []float64 {
+		return []float64{
+			c.agent.P().X(), c.agent.P().Y(), c.agent.V().X(), c.agent.V().Y(), c.agent.R(),
+			c.obstacle.P().X(), c.obstacle.P().Y(), c.obstacle.V().X(), c.obstacle.V().Y(), c.obstacle.R(),
+			c.tau,
+		}
+	}
+	decode := func(fs []float64) config {
+		return config{
+			agent:    *agent.New(agent.O{P: *vector.New(fs[0], fs[1]), V: *vector.New(fs[2], fs[3]), R: fs[4]}),
+			obstacle: *agent.New(agent.O{P: *vector.New(fs[5], fs[6]), V: *vector.New(fs[7], fs[8]), R: fs[9]}),
+			tau:      fs[10],
+		}
+	}
+	literal := func(c config) string {
+		a := func(a agent.A) string {
+			return fmt.Sprintf(
+				"*agent.New(agent.O{P: *vector.New(%v, %v), V: *vector.New(%v, %v), R: %v})",
+				a.P().X(), a.P().Y(), a.V().X(), a.V().Y(), a.R())
+		}
+		return fmt.Sprintf(
+			"{\n\tname:     %q,\n\tagent:    %v,\n\tobstacle: %v,\n\ttau:      %v,\n},",
+			c.name, a(c.agent), a(c.obstacle), c.tau)
+	}
+
+	vo := func(c config) *VO {
+		v, err := New(O{
+			Agent:    c.agent,
+			Obstacle: c.obstacle,
+			Tau:      c.tau,
+			Weight:   opt.WeightEqual,
+			VOpt:     opt.VOptV,
+		})
+		if err != nil {
+			panic(err)
+		}
+		return v
+	}
+	// check returns the ORCA planes generated by the VO under test and
+	// the reference implementation, and if the planes match.
+	check := func(c config) (hyperplane.HP, hyperplane.HP, bool) {
+		got, err := vo(c).ORCA()
+		if err != nil {
+			panic(err)
+		}
+		want, err := reference.New(c.obstacle, c.agent, c.tau).ORCA()
+		if err != nil {
+			panic(err)
+		}
+
+		// The tolerance is relative to the scale of the input
+		// velocities.
+		s := math.Max(1, math.Max(
+			vector.Magnitude(vector.Sub(c.agent.V(), c.obstacle.V())),
+			vector.Magnitude(vector.Sub(c.obstacle.P(), c.agent.P()))/c.tau,
+		))
+		return got, want, conformance.Within(got, want, tol*s)
+	}
+
+	// g generates an agent and an obstacle whose relative velocity lies
+	// near the center of the truncation circle, offset by a vector w whose
+	// magnitude spans several orders of magnitude, which ensures all
+	// domains are covered.
+	g := func(r *rand.Rand) config {
+		u := func() vector.V {
+			theta := 2 * math.Pi * r.Float64()
+			return *vector.New(math.Cos(theta), math.Sin(theta))
+		}
+		a := *agent.New(agent.O{
+			P: *vector.New(200*r.Float64()-100, 200*r.Float64()-100),
+			V: *vector.New(20*r.Float64()-10, 20*r.Float64()-10),
+			R: 10*r.Float64() + minTau,
+		})
+		rb := 10*r.Float64() + minTau
+		tau := math.Pow(10, 4*r.Float64()-2)
+
+		// Collisions occur for roughly a third of the inputs.
+		p := vector.Scale((a.R()+rb)*3*r.Float64()+minTau, u())
+		w := vector.Scale(vector.Magnitude(p)/tau*math.Pow(10, 3*r.Float64()-2), u())
+		return config{
+			agent: a,
+			obstacle: *agent.New(agent.O{
+				P: vector.Add(a.P(), p),
+				V: vector.Sub(a.V(), vector.Add(vector.Scale(1/tau, p), w)),
+				R: rb,
+			}),
+			tau: tau,
+		}
+	}
+
+	// ds is listed in a fixed order to ensure the test names are
+	// reproducible between runs.
+	ds := []domain.D{domain.Left, domain.Right, domain.Circle, domain.Collision}
+
+	r := rand.New(rand.NewSource(0))
+	ts, err := conformance.Sample(r, conformance.O[config, domain.D]{
+		G:       g,
+		D:       func(c config) domain.D { return vo(c).domain() },
+		Domains: ds,
+		N:       n,
+	})
+	if err != nil {
+		t.Fatalf("Sample() returned an unexpected error: %v", err)
+	}
+	for i, c := range ts.Panics {
+		c.name = fmt.Sprintf("Random/Panic/%v", i)
+		t.Errorf("domain() panicked for input:\n%v", literal(c))
+	}
+	for _, d := range ds {
+		for i, c := range ts.T[d] {
+			c.name = fmt.Sprintf("Random/Domain=%v/%v", d, i)
+			testConfigs = append(testConfigs, c)
+		}
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got, want, ok := check(c); !ok {
+				m := decode(conformance.Minimize(encode(c), func(fs []float64) bool {
+					c := decode(fs)
+					if c.agent.R() <= 0 || c.obstacle.R() <= 0 || c.tau < minTau {
+						return false
+					}
+					// Overlapping agents are separated
+					// along a random direction, and
+					// therefore are not reproducible.
+					if vector.Within(c.agent.P(), c.obstacle.P()) {
+						return false
+					}
+					_, _, ok := check(c)
+					return !ok
+				}))
+				m.name = "Minimized"
+				t.Errorf("ORCA() = %v, want = %v; minimized input:\n%v", got, want, literal(m))
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ const (
 
 func VOptV(agent agent.A) vector.V    { return agent.V() }
 func VOptZero(agent agent.A) vector.V { return *vector.New(0, 0) }
+func VOptT(agent agent.A) vector.V    { return agent.T() }
 
 // Weight is the relative responsibility the input agent needs to take
 // for steering away from the obstacle -- for ball-ball interactions,
@@ -27,11 +28,10 @@ type Weight float64
 // to the agent velocity, but for ball-wall interactions, this is set to
 // the 0-vector instead.
 //
-// N.B.: Note that in van den Berg et al., the optimal velocity is also
-// used to calculate the vector u which touches the nearest side of the
-// VO. Per the RVO2 implementation, this does not appear to actually be
-// the case, and VOpt is only used in calculating the base of the ORCA
-// plane.
+// The relative optimal velocity of the two agents is also used to
+// calculate the vector u which touches the nearest side of the VO. RVO2
+// only supports the current velocity as the optimal velocity, in which
+// case this is the usual relative velocity.
 type VOpt func(agent agent.A) vector.V
 
 type O struct {
@@ -0,0 +1,410 @@
+// Package arc defines a velocity obstacle object which is constructed from a
+// (possibly thick) circular arc.
+//
+// The arc is impermeable from either side.
+//
+// The truncated VO of an arc is the union of the truncated VOs of each point on
+// the arc, i.e.
+//
+//	VO = ⋃ { VO(a) | a ∈ A }
+//
+// where VO(a) is the usual truncated cone generated by a static disc of radius
+// W centered at a, and W is the radius of the arc, i.e. half its thickness.
+// Equivalently, if X is the set of points in v-space within R / 𝜏 of the scaled
+// arc A' (where R is the combined radius of the agent and the arc), then
+//
+//	VO = { v | λv ∈ X for some 0 < λ ≤ 1 }
+//
+// i.e. v lies within the VO if the segment between the origin and v passes
+// within R / 𝜏 of A'.
+//
+// The ORCA plane is tangent to the VO at the point on the VO boundary closest
+// to v. The boundary consists of the parts of the boundary of X which are
+// visible from the origin, and the two tangent legs of the VO, which touch X at
+// the points which are angularly extreme as viewed from the origin.
+//
+// If v lies outside the VO, the nearest point on A' defines the tangent plane,
+// unless v lies closer to one of the legs. If the nearest point is one of the
+// ends of the arc, the plane is tangent to the rounded cap generated by the
+// end, analogous to the LeftCircle and RightCircle domains of the wall VO.
+//
+// If v lies within the VO, v exits the VO via the shortest path, i.e. the plane
+// is tangent to the VO at the closest point on the visible boundary of X or on
+// the legs.
+package arc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/internal/vo/arc/domain"
+	"github.com/downflux/go-orca/region/arc"
+)
+
+type VO struct {
+	obstacle arc.A
+
+	// r is the radius of the obstacle, i.e. half its thickness.
+	r float64
+}
+
+// New constructs a VO for an arc with the input radius. A zero-width arc may
+// be constructed by setting r = 0.
+func New(obstacle arc.A, r float64) *VO {
+	if r < 0 {
+		panic(fmt.Sprintf("cannot construct VO object, arc radius %v is negative", r))
+	}
+	return &VO{
+		obstacle: obstacle,
+		r:        r,
+	}
+}
+
+func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP {
+	_, hp := vo.orca(a, tau)
+	return hp
+}
+
+// domain returns the part of the VO boundary which generates the ORCA plane.
+func (vo VO) domain(a agent.A, tau float64) domain.D {
+	d, _ := vo.orca(a, tau)
+	return d
+}
+
+func (vo VO) orca(a agent.A, tau float64) (domain.D, hyperplane.HP) {
+	// Agent physically collides with the arc; per van den Berg et al.
+	// (2011), the agent is pushed directly away from the closest point on
+	// the arc, with a VOpt of the 0-vector.
+	q := vo.obstacle.L(vo.obstacle.T(a.P()))
+	if d := vector.Magnitude(vector.Sub(a.P(), q)); d < a.R()+vo.r || epsilon.Within(d, a.R()+vo.r) {
+		m := vector.Sub(q, vo.obstacle.C())
+		if !epsilon.Within(d, 0) {
+			m = vector.Sub(a.P(), q)
+		}
+		return domain.Collision, *hyperplane.New(opt.VOptZero(a), vector.Unit(m))
+	}
+
+	s := *arc.New(
+		vector.Scale(1/tau, vector.Sub(vo.obstacle.C(), a.P())),
+		vo.obstacle.R()/tau,
+		vo.obstacle.TMin(),
+		vo.obstacle.TMax(),
+	)
+	r := (a.R() + vo.r) / tau
+	v := a.V()
+
+	var p candidate
+	var in bool
+	if in = hidden(s, r, v); !in {
+		p = outside(s, r, v)
+	} else {
+		p = inside(s, r, v)
+	}
+
+	m := p.n
+	if w := vector.Sub(v, p.p); !epsilon.Within(vector.Magnitude(w), 0) {
+		m = vector.Unit(w)
+		if in {
+			m = vector.Scale(-1, m)
+		}
+	}
+	return p.d, *hyperplane.New(p.p, m)
+}
+
+// candidate is a point on the boundary of the VO, along with the outward unit
+// normal of the VO at the point, and the associated domain.
+type candidate struct {
+	p vector.V
+	n vector.V
+	d domain.D
+}
+
+// outside returns the point on the VO boundary closest to the input vector v,
+// which lies outside the VO generated by the scaled arc s, rounded by r.
+//
+// Because X (i.e. s rounded by r) is a subset of the VO, any point on the
+// boundary of X which is closest to v is either also on the boundary of the
+// VO, or is hidden behind one of the legs, in which case the leg is closer to
+// v.
+func outside(s arc.A, r float64, v vector.V) candidate {
+	t := s.T(v)
+	n := unit(vector.Sub(v, s.L(t)), vector.Sub(s.L(t), s.C()))
+
+	c := candidate{
+		p: vector.Add(s.L(t), vector.Scale(r, n)),
+		n: n,
+		d: end(s, t),
+	}
+	d := vector.Magnitude(vector.Sub(v, c.p))
+	for _, l := range legs(s, r) {
+		if k := vector.Dot(vector.Sub(v, l.p), l.d); k > 0 {
+			p := vector.Add(l.p, vector.Scale(k, l.d))
+			if e := vector.Magnitude(vector.Sub(v, p)); e < d {
+				c, d = candidate{p: p, n: l.n, d: l.dm}, e
+			}
+		}
+	}
+	return c
+}
+
+// inside returns the point on the VO boundary closest to the input vector v,
+// which lies within the VO generated by the scaled arc s, rounded by r.
+//
+// The boundary of X consists of the outer and inner arcs concentric with s,
+// and the rounded caps around the ends of s. The closest point on the VO
+// boundary is either the closest point on one of these curves, the closest
+// point on one of the legs, or a junction between two curves, and must be
+// visible from the origin.
+func inside(s arc.A, r float64, v vector.V) candidate {
+	var cs []candidate
+
+	// Add the closest points on the outer and inner arcs.
+	for _, k := range []float64{1, -1} {
+		if k*r+s.R() <= 0 {
+			continue
+		}
+		t := s.T(v)
+		n := vector.Scale(k, unit(vector.Sub(s.L(t), s.C()), *vector.New(1, 0)))
+		cs = append(cs, candidate{
+			p: vector.Add(s.L(t), vector.Scale(r, n)),
+			n: n,
+			d: end(s, t),
+		})
+	}
+
+	// Add the closest points on the caps, and the junctions between the
+	// caps and the arcs.
+	for _, t := range []float64{s.TMin(), s.TMax()} {
+		e := s.L(t)
+		m := unit(vector.Sub(e, s.C()), *vector.New(1, 0))
+		n := unit(vector.Sub(v, e), m)
+		for _, n := range []vector.V{n, m, vector.Scale(-1, m)} {
+			cs = append(cs, candidate{
+				p: vector.Add(e, vector.Scale(r, n)),
+				n: n,
+				d: end(s, t),
+			})
+		}
+	}
+
+	// Add the junctions between the two caps.
+	a, b := s.L(s.TMin()), s.L(s.TMax())
+	if h := vector.Sub(b, a); vector.Magnitude(h) < 2*r && !epsilon.Within(vector.Magnitude(h), 0) {
+		m := vector.Scale(0.5, vector.Add(a, b))
+		w := math.Sqrt(r*r - vector.SquaredMagnitude(h)/4)
+		for _, k := range []float64{1, -1} {
+			p := vector.Add(m, vector.Scale(k*w, vector.Unit(*vector.New(-h.Y(), h.X()))))
+			cs = append(cs, candidate{
+				p: p,
+				n: vector.Unit(vector.Sub(p, a)),
+				d: domain.MinCircle,
+			})
+		}
+	}
+
+	// Add the closest points on the legs.
+	for _, l := range legs(s, r) {
+		k := math.Max(0, vector.Dot(vector.Sub(v, l.p), l.d))
+		cs = append(cs, candidate{
+			p: vector.Add(l.p, vector.Scale(k, l.d)),
+			n: l.n,
+			d: l.dm,
+		})
+	}
+
+	var c candidate
+	d := math.Inf(1)
+	for _, p := range cs {
+		// Skip points which lie in the interior of X, e.g. the
+		// closest point on the outer arc may lie within one of the
+		// caps, or which are occluded by X.
+		if e := s.Distance(p.p); e < r && !epsilon.Within(e, r) {
+			continue
+		}
+		if hidden(s, r, p.p) {
+			continue
+		}
+		if e := vector.Magnitude(vector.Sub(v, p.p)); e < d {
+			c, d = p, e
+		}
+	}
+	return c
+}
+
+// leg is a tangent leg of the VO.
+type leg struct {
+	// p is the point at which the leg touches X.
+	p vector.V
+
+	// d is the unit direction of the leg, directed away from the origin.
+	d vector.V
+
+	// n is the outward unit normal of the VO along the leg.
+	n vector.V
+
+	dm domain.D
+}
+
+// legs returns the left and right tangent legs of the VO generated by the
+// scaled arc s, rounded by r.
+//
+// The legs are tangent to X at the points which are angularly extreme as
+// viewed from the origin, which lie either on the outer arc of radius
+// s.R() + r, or on the caps around the ends of the arc.
+func legs(s arc.A, r float64) []leg {
+	// tangents returns the two lines through the origin which are tangent
+	// to the circle with center p and radius k, as the polar angle of the
+	// tangent line, and the distance between the origin and the tangent
+	// point.
+	type tangent struct {
+		phi float64
+		d   float64
+	}
+	tangents := func(p vector.V, k float64) []tangent {
+		t := math.Atan2(p.Y(), p.X())
+		b := math.Asin(k / vector.Magnitude(p))
+		d := math.Sqrt(vector.SquaredMagnitude(p) - k*k)
+		return []tangent{{t + b, d}, {t - b, d}}
+	}
+	l := func(t tangent) leg {
+		d := *vector.New(math.Cos(t.phi), math.Sin(t.phi))
+		return leg{p: vector.Scale(t.d, d), d: d}
+	}
+
+	var left, right leg
+	if vector.Magnitude(s.C()) <= s.R() {
+		// If the origin lies within the arc circle, the polar angle
+		// (as viewed from the origin) of a point on the arc increases
+		// monotonically with t, and the extreme points lie on the caps.
+		left = l(tangents(s.L(s.TMax()), r)[0])
+		right = l(tangents(s.L(s.TMin()), r)[1])
+	} else {
+		// Otherwise, X subtends an angle of at most π on either side
+		// of the midpoint of the arc, and we may compare polar angles
+		// relative to the midpoint.
+		m := s.L((s.TMin() + s.TMax()) / 2)
+		theta := math.Atan2(m.Y(), m.X())
+
+		var ts []tangent
+		for _, t := range []float64{s.TMin(), s.TMax()} {
+			ts = append(ts, tangents(s.L(t), r)...)
+		}
+		if vector.Magnitude(s.C()) > s.R()+r {
+			for _, t := range tangents(s.C(), s.R()+r) {
+				// The tangent point lies on the outer circle, and
+				// must also lie on the outer arc.
+				if p := vector.Sub(l(t).p, s.C()); in(s, math.Atan2(p.Y(), p.X())) {
+					ts = append(ts, t)
+				}
+			}
+		}
+
+		rel := func(t tangent) float64 { return math.Remainder(t.phi-theta, 2*math.Pi) }
+		lt, rt := ts[0], ts[0]
+		for _, t := range ts[1:] {
+			if rel(t) > rel(lt) {
+				lt = t
+			}
+			if rel(t) < rel(rt) {
+				rt = t
+			}
+		}
+		left, right = l(lt), l(rt)
+	}
+
+	// The VO lies clockwise of the left leg, and counter-clockwise of the
+	// right leg.
+	left.n, left.dm = *vector.New(-left.d.Y(), left.d.X()), domain.Left
+	right.n, right.dm = *vector.New(right.d.Y(), -right.d.X()), domain.Right
+	return []leg{left, right}
+}
+
+// hidden checks if the input vector v lies strictly within the VO generated by
+// the scaled arc s, rounded by r, i.e. if the segment between the origin and v
+// passes strictly within r of s.
+func hidden(s arc.A, r float64, v vector.V) bool {
+	d := distance(s, *vector.New(0, 0), v)
+	return d < r && !epsilon.Within(d, r)
+}
+
+// distance returns the minimum distance between the segment with ends p and q
+// and the arc s.
+func distance(s arc.A, p vector.V, q vector.V) float64 {
+	// segment returns the distance between the segment and the input
+	// vector.
+	segment := func(v vector.V) float64 {
+		w := vector.Sub(q, p)
+		k := 0.0
+		if m := vector.SquaredMagnitude(w); m > 0 {
+			k = math.Max(0, math.Min(1, vector.Dot(vector.Sub(v, p), w)/m))
+		}
+		return vector.Magnitude(vector.Sub(v, vector.Add(p, vector.Scale(k, w))))
+	}
+
+	d := math.Min(
+		math.Min(s.Distance(p), s.Distance(q)),
+		math.Min(segment(s.L(s.TMin())), segment(s.L(s.TMax()))),
+	)
+
+	w := vector.Sub(q, p)
+	m := vector.SquaredMagnitude(w)
+	if m == 0 {
+		return d
+	}
+
+	// The segment and the arc may also be closest at a pair of interior
+	// points, i.e. where the segment is perpendicular to the radius of the
+	// arc circle, or the segment may intersect the arc.
+	k := vector.Dot(vector.Sub(s.C(), p), w) / m
+	f := vector.Add(p, vector.Scale(k, w))
+	h := vector.Magnitude(vector.Sub(f, s.C()))
+	if 0 <= k && k <= 1 && !epsilon.Within(h, 0) {
+		if g := vector.Sub(f, s.C()); in(s, math.Atan2(g.Y(), g.X())) {
+			d = math.Min(d, math.Abs(h-s.R()))
+		}
+	}
+	if h < s.R() {
+		o := math.Sqrt(s.R()*s.R()-h*h) / math.Sqrt(m)
+		for _, j := range []float64{k - o, k + o} {
+			if j < 0 || j > 1 {
+				continue
+			}
+			if g := vector.Sub(vector.Add(p, vector.Scale(j, w)), s.C()); in(s, math.Atan2(g.Y(), g.X())) {
+				return 0
+			}
+		}
+	}
+	return d
+}
+
+// in checks if the input polar angle lies within the angular span of the arc.
+func in(s arc.A, t float64) bool {
+	return math.Mod(math.Mod(t-s.TMin(), 2*math.Pi)+2*math.Pi, 2*math.Pi) <= s.TMax()-s.TMin()
+}
+
+// end returns the domain associated with the point on the arc at the input
+// polar angle.
+func end(s arc.A, t float64) domain.D {
+	switch t {
+	case s.TMin():
+		return domain.MinCircle
+	case s.TMax():
+		return domain.MaxCircle
+	default:
+		return domain.Arc
+	}
+}
+
+// unit returns the unit vector of v, or the unit vector of the fallback f if v
+// is the 0-vector.
+func unit(v vector.V, f vector.V) vector.V {
+	if epsilon.Within(vector.Magnitude(v), 0) {
+		return vector.Unit(f)
+	}
+	return vector.Unit(v)
+}
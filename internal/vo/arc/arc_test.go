@@ -0,0 +1,190 @@
+package arc
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/internal/vo/arc/domain"
+	"github.com/downflux/go-orca/region/arc"
+	"github.com/downflux/go-orca/vo"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+	vofootprint "github.com/downflux/go-orca/internal/vo/footprint"
+)
+
+var (
+	_ vo.VO = VO{}
+)
+
+func TestORCA(t *testing.T) {
+	// a is the bottom arc of the circle of radius 5 centered at (0, 10),
+	// which passes through (0, 5).
+	a := *arc.New(*vector.New(0, 10), 5, -math.Pi/2-0.5, -math.Pi/2+0.5)
+
+	type config struct {
+		name  string
+		arc   arc.A
+		r     float64
+		agent agentimpl.A
+		tau   float64
+
+		domain domain.D
+		want   hyperplane.HP
+	}
+
+	testConfigs := []config{
+		{
+			name:   "Arc",
+			arc:    a,
+			agent:  *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 2), R: 1}),
+			tau:    1,
+			domain: domain.Arc,
+			want:   *hyperplane.New(*vector.New(0, 4), *vector.New(0, -1)),
+		},
+		{
+			name:   "Arc/Thick",
+			arc:    a,
+			r:      1,
+			agent:  *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 2), R: 1}),
+			tau:    1,
+			domain: domain.Arc,
+			want:   *hyperplane.New(*vector.New(0, 3), *vector.New(0, -1)),
+		},
+		// The agent lies within the arc circle, and is moving towards
+		// the concave side of the arc.
+		{
+			name:   "Arc/Concave",
+			arc:    a,
+			agent:  *agentimpl.New(agentimpl.O{P: *vector.New(0, 10), V: *vector.New(0, -1), R: 1}),
+			tau:    1,
+			domain: domain.Arc,
+			want:   *hyperplane.New(*vector.New(0, -4), *vector.New(0, 1)),
+		},
+		{
+			name:   "Collision",
+			arc:    a,
+			agent:  *agentimpl.New(agentimpl.O{P: *vector.New(0, 4.5), V: *vector.New(0, 2), R: 1}),
+			tau:    1,
+			domain: domain.Collision,
+			want:   *hyperplane.New(*vector.New(0, 0), *vector.New(0, -1)),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(c.arc, c.r)
+			if got := v.domain(c.agent, c.tau); got != c.domain {
+				t.Errorf("domain() = %v, want = %v", got, c.domain)
+			}
+			if got := v.ORCA(c.agent, c.tau); !hyperplane.WithinEpsilon(got, c.want, epsilon.Absolute(1e-5)) {
+				t.Errorf("ORCA() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestCircle checks that the VO generated by a velocity which is closest to one
+// of the ends of the arc, or to one of the tangent legs, matches the VO of a
+// static disc at that end.
+func TestCircle(t *testing.T) {
+	a := *arc.New(*vector.New(0, 10), 5, -math.Pi/2-0.5, -math.Pi/2+0.5)
+
+	type config struct {
+		name   string
+		v      vector.V
+		domain domain.D
+		t      float64
+	}
+
+	testConfigs := []config{
+		{name: "MaxCircle", v: *vector.New(3.2, 4.4), domain: domain.MaxCircle, t: a.TMax()},
+		{name: "Right", v: *vector.New(10, 2), domain: domain.Right, t: a.TMax()},
+		{name: "Left", v: *vector.New(-10, 2), domain: domain.Left, t: a.TMin()},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			agent := *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: c.v, R: 1})
+			if got := New(a, 0).domain(agent, 1); got != c.domain {
+				t.Fatalf("domain() = %v, want = %v", got, c.domain)
+			}
+
+			got := New(a, 0).ORCA(agent, 1)
+			want := vofootprint.New(
+				*agentimpl.New(agentimpl.O{P: a.L(c.t), V: *vector.New(0, 0)}),
+				opt.O{Weight: opt.WeightAll, VOpt: opt.VOptV},
+			).ORCA(agent, 1)
+			if !hyperplane.WithinEpsilon(got, want, epsilon.Absolute(1e-10)) {
+				t.Errorf("ORCA() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+// TestMinimum checks that the ORCA plane is generated by the closest point on
+// the boundary of the union of the individual point VOs, by comparing against a
+// dense sampling of the arc.
+//
+// If the agent velocity lies outside the VO, the distance to the plane is the
+// distance to the closest point VO. Otherwise, the agent must move at least as
+// far to exit the union as it would to exit any single point VO.
+func TestMinimum(t *testing.T) {
+	const n = 100
+	const m = 2000
+
+	r := rand.New(rand.NewSource(0))
+	rn := func() float64 { return 20*r.Float64() - 10 }
+
+	for i := 0; i < n; i++ {
+		tmin := 2 * math.Pi * r.Float64()
+		a := *arc.New(*vector.New(rn(), rn()), 1+5*r.Float64(), tmin, tmin+2*math.Pi*r.Float64()+1e-3)
+		agent := *agentimpl.New(agentimpl.O{P: *vector.New(rn(), rn()), V: *vector.New(rn(), rn()), R: r.Float64() + 0.1})
+
+		t.Run(fmt.Sprintf("Random-%v", i), func(t *testing.T) {
+			v := New(a, 0)
+			if v.domain(agent, 1) == domain.Collision {
+				t.Skip("agent collides with the arc")
+			}
+
+			// d returns the minimum signed distance between the
+			// input velocity and the individual point VOs.
+			d := func(u vector.V) float64 {
+				agent := *agentimpl.New(agentimpl.O{P: agent.P(), V: u, R: agent.R()})
+				d := math.Inf(1)
+				for j := 0; j < m; j++ {
+					hp := vofootprint.New(
+						*agentimpl.New(agentimpl.O{
+							P: a.L(a.TMin() + (a.TMax()-a.TMin())*float64(j)/(m-1)),
+							V: *vector.New(0, 0),
+						}),
+						opt.O{Weight: opt.WeightAll, VOpt: opt.VOptV},
+					).ORCA(agent, 1)
+					d = math.Min(d, vector.Dot(vector.Sub(u, hp.P()), hp.N()))
+				}
+				return d
+			}
+
+			hp := v.ORCA(agent, 1)
+			want := d(agent.V())
+			got := vector.Dot(vector.Sub(agent.V(), hp.P()), hp.N())
+			if got > want+1e-6 {
+				t.Errorf("ORCA() distance = %v, want <= %v", got, want)
+			}
+			if want > 0 && got < want-1e-3 {
+				t.Errorf("ORCA() distance = %v, want = %v", got, want)
+			}
+
+			// The plane must be tangent to the boundary of the
+			// union.
+			if u := vector.Add(hp.P(), vector.Scale(1e-6, hp.N())); d(u) < 0 {
+				t.Errorf("ORCA() = %v, which does not lie on the VO boundary", hp)
+			}
+		})
+	}
+}
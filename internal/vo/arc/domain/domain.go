@@ -0,0 +1,40 @@
+package domain
+
+type D int
+
+const (
+	// Collision indicates a physical overlap between the obstacle and the
+	// agent.
+	Collision D = iota
+
+	// MinCircle and MaxCircle indicate the relative velocity lies closest
+	// to the truncated VO generated by the TMin and TMax ends of the arc,
+	// respectively.
+	MinCircle
+	MaxCircle
+
+	// Arc indicates the relative velocity lies closest to the truncated
+	// VO generated by an interior point of the arc.
+	Arc
+
+	// Left and Right indicate the relative velocity lies closest to the
+	// left and right tangent legs of the VO, respectively, as viewed from
+	// the origin.
+	Left
+	Right
+)
+
+func (d D) String() string {
+	v, ok := map[D]string{
+		Collision: "COLLISION",
+		MinCircle: "MIN_CIRCLE",
+		MaxCircle: "MAX_CIRCLE",
+		Arc:       "ARC",
+		Left:      "LEFT",
+		Right:     "RIGHT",
+	}[d]
+	if !ok {
+		return "UNKNOWN"
+	}
+	return v
+}
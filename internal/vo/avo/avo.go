@@ -0,0 +1,93 @@
+// Package avo defines a velocity obstacle wrapper which adjusts an underlying
+// VO for acceleration-constrained agents.
+//
+// Consider an agent which changes its velocity from v to v' at a constant
+// acceleration over some timestep 𝛥t (and moves at v' thereafter). At any
+// time t ≥ 𝛥t, the position of the agent is then
+//
+//	P(t) = P + v't - (v' - v)𝛿
+//
+// where 𝛿 := 𝛥t / 2. This is equivalent to an agent which changes its
+// velocity instantaneously, but starts at the position P + 𝛿v, and starts
+// moving 𝛿 later. We may therefore conservatively account for the
+// acceleration delay by constructing the usual VO for the shifted agent
+// position (relative to the obstacle), with a lookahead time 𝜏 - 𝛿.
+//
+// N.B.: The relative shift assumes both the agent and the obstacle share the
+// same timestep, as is the case in a simulation where all agents are updated
+// together.
+package avo
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/acceleration"
+	"github.com/downflux/go-orca/agent/footprint"
+	"github.com/downflux/go-orca/vo"
+)
+
+const (
+	minTau = 1e-3
+)
+
+type VO struct {
+	vo vo.VO
+
+	// v is the velocity of the obstacle of the underlying VO.
+	v vector.V
+}
+
+// New wraps the input VO, whose obstacle is moving with velocity v.
+func New(vo vo.VO, v vector.V) *VO {
+	return &VO{
+		vo: vo,
+		v:  v,
+	}
+}
+
+// ORCA returns the half-plane of permissable velocities for the input agent.
+//
+// If the input agent does not implement (or wrap) acceleration.A, the
+// underlying VO is returned unmodified.
+func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP {
+	b, ok := acceleration.Unwrap(a)
+	if !ok {
+		return vo.vo.ORCA(a, tau)
+	}
+
+	d := b.DT() / 2
+	return vo.vo.ORCA(
+		shift(a, vector.Add(a.P(), vector.Scale(d, vector.Sub(a.V(), vo.v)))),
+		math.Max(minTau, tau-d),
+	)
+}
+
+// shift returns a copy of the input agent at the input position. The returned
+// agent retains the footprint of the input agent, if set.
+func shift(a agent.A, p vector.V) agent.A {
+	s := s{A: a, p: p}
+	if f, ok := a.(footprint.A); ok {
+		return sf{s: s, f: f.F()}
+	}
+	return s
+}
+
+type s struct {
+	agent.A
+	p vector.V
+}
+
+func (s s) P() vector.V { return s.p }
+
+// Unwrap returns the underlying (unshifted) agent.
+func (s s) Unwrap() agent.A { return s.A }
+
+type sf struct {
+	s
+	f footprint.F
+}
+
+func (s sf) F() footprint.F { return s.f }
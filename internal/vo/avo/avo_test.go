@@ -0,0 +1,167 @@
+package avo
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/acceleration"
+	"github.com/downflux/go-orca/agent/footprint"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/vo"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+	voagent "github.com/downflux/go-orca/internal/vo/agent"
+)
+
+var (
+	_ vo.VO          = VO{}
+	_ acceleration.A = a{}
+	_ footprint.A    = sf{}
+)
+
+// a is an agent with a maximum acceleration.
+type a struct {
+	agentimpl.A
+	accel float64
+	dt    float64
+}
+
+func (a a) Accel() float64 { return a.accel }
+func (a a) DT() float64    { return a.dt }
+
+// w is an agent wrapper which hides the optional interfaces of the underlying
+// agent.
+type w struct {
+	agent.A
+}
+
+func (w w) Unwrap() agent.A { return w.A }
+
+// f is an agent with an explicit footprint.
+type f struct {
+	agentimpl.A
+	f footprint.F
+}
+
+func (f f) F() footprint.F { return f.f }
+
+func TestORCA(t *testing.T) {
+	o := opt.O{
+		Weight: opt.WeightEqual,
+		VOpt:   opt.VOptV,
+	}
+	obstacle := *agentimpl.New(agentimpl.O{
+		P: *vector.New(0, 5),
+		V: *vector.New(0, -1),
+		R: 1,
+	})
+
+	type config struct {
+		name  string
+		agent agent.A
+		tau   float64
+		want  hyperplane.HP
+	}
+
+	testConfigs := []config{
+		{
+			name: "Passthrough",
+			agent: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 0),
+				V: *vector.New(1, 1),
+				R: 1,
+			}),
+			tau: 2,
+			want: voagent.New(obstacle, o).ORCA(
+				*agentimpl.New(agentimpl.O{
+					P: *vector.New(0, 0),
+					V: *vector.New(1, 1),
+					R: 1,
+				}),
+				2,
+			),
+		},
+		// The relative velocity of the agent is (1, 2); the agent
+		// takes 𝛥t = 0.5 to change velocities, so the equivalent
+		// instantaneous VO is constructed from the agent at
+		// P + 𝛿v = (0.25, 0.5), with a lookahead time of 𝜏 - 𝛿.
+		{
+			name: "Shifted",
+			agent: a{
+				A: *agentimpl.New(agentimpl.O{
+					P: *vector.New(0, 0),
+					V: *vector.New(1, 1),
+					R: 1,
+				}),
+				accel: 1,
+				dt:    0.5,
+			},
+			tau: 2,
+			want: voagent.New(obstacle, o).ORCA(
+				*agentimpl.New(agentimpl.O{
+					P: *vector.New(0.25, 0.5),
+					V: *vector.New(1, 1),
+					R: 1,
+				}),
+				1.75,
+			),
+		},
+		// Wrapped agents are still acceleration-constrained.
+		{
+			name: "Shifted/Wrapped",
+			agent: w{
+				A: a{
+					A: *agentimpl.New(agentimpl.O{
+						P: *vector.New(0, 0),
+						V: *vector.New(1, 1),
+						R: 1,
+					}),
+					accel: 1,
+					dt:    0.5,
+				},
+			},
+			tau: 2,
+			want: voagent.New(obstacle, o).ORCA(
+				*agentimpl.New(agentimpl.O{
+					P: *vector.New(0.25, 0.5),
+					V: *vector.New(1, 1),
+					R: 1,
+				}),
+				1.75,
+			),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got := New(voagent.New(obstacle, o), obstacle.V()).ORCA(c.agent, c.tau)
+			if !hyperplane.Within(got, c.want) {
+				t.Errorf("ORCA() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestShift(t *testing.T) {
+	p := *vector.New(1, 2)
+	t.Run("Circle", func(t *testing.T) {
+		got := shift(*agentimpl.New(agentimpl.O{R: 1}), p)
+		if !vector.Within(got.P(), p) {
+			t.Errorf("P() = %v, want = %v", got.P(), p)
+		}
+		if _, ok := got.(footprint.A); ok {
+			t.Errorf("shift() unexpectedly implements footprint.A")
+		}
+	})
+	t.Run("Footprint", func(t *testing.T) {
+		got := shift(f{A: *agentimpl.New(agentimpl.O{R: 1}), f: *footprint.NewCircle(1)}, p)
+		if !vector.Within(got.P(), p) {
+			t.Errorf("P() = %v, want = %v", got.P(), p)
+		}
+		if _, ok := got.(footprint.A); !ok {
+			t.Errorf("shift() does not implement footprint.A")
+		}
+	})
+}
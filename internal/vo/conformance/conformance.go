@@ -0,0 +1,178 @@
+// Package conformance implements a randomized differential test harness, which
+// checks the VOs defined in internal/vo against the Golang transpilations of
+// the official RVO2 implementation in external/snape/RVO2.
+//
+// The harness samples random inputs, and buckets the inputs by the domain of
+// the VO under test, e.g. the collision domain, or the left leg of the VO cone.
+// As some domains are only hit by a small fraction of the input space, we
+// sample until every domain has been covered by a minimum number of inputs.
+//
+// The caller then compares the ORCA planes generated by the two
+// implementations. Any mismatching input may be passed to Minimize, which
+// searches for a simpler input (e.g. with fewer significant digits) that still
+// fails; the minimized input may then be added to the manual test cases as a
+// reproducible regression test.
+package conformance
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// attempts is the number of random inputs sampled per requested input
+	// before the sampler gives up on covering a domain.
+	attempts = 100
+
+	// digits is the maximum number of decimal digits retained by Minimize
+	// when rounding an input parameter.
+	digits = 6
+)
+
+// O is an options struct passed into Sample.
+type O[T any, D comparable] struct {
+	// G generates a random input.
+	G func(r *rand.Rand) T
+
+	// D returns the domain of the input, as classified by the VO under
+	// test.
+	D func(t T) D
+
+	// Domains is the set of domains which must be covered by the sampled
+	// inputs.
+	Domains []D
+
+	// N is the number of inputs sampled per domain.
+	N int
+}
+
+// S is the set of inputs generated by Sample.
+type S[T any, D comparable] struct {
+	// T is the list of sampled inputs, bucketed by domain.
+	T map[D][]T
+
+	// Panics is the list of generated inputs for which the domain
+	// classification panicked, e.g. degenerate inputs. These inputs are
+	// not bucketed, and should be reported by the caller.
+	Panics []T
+
+	// N is the total number of generated inputs.
+	N int
+}
+
+// Sample generates N random inputs per domain. Sample returns an error if some
+// domain could not be covered by the generator after a reasonable number of
+// attempts.
+func Sample[T any, D comparable](r *rand.Rand, o O[T, D]) (S[T, D], error) {
+	s := S[T, D]{T: make(map[D][]T, len(o.Domains))}
+	want := make(map[D]bool, len(o.Domains))
+	for _, d := range o.Domains {
+		want[d] = true
+	}
+
+	remaining := len(o.Domains)
+	for ; remaining > 0 && s.N < attempts*o.N*len(o.Domains); s.N++ {
+		t := o.G(r)
+		d, ok := classify(o.D, t)
+		if !ok {
+			s.Panics = append(s.Panics, t)
+			continue
+		}
+		if !want[d] || len(s.T[d]) >= o.N {
+			continue
+		}
+		if s.T[d] = append(s.T[d], t); len(s.T[d]) == o.N {
+			remaining--
+		}
+	}
+
+	for _, d := range o.Domains {
+		if len(s.T[d]) < o.N {
+			return S[T, D]{}, status.Errorf(codes.ResourceExhausted, "could only generate %v / %v inputs for domain %v", len(s.T[d]), o.N, d)
+		}
+	}
+	return s, nil
+}
+
+// Within checks if two ORCA planes are equal within the input absolute
+// tolerance, i.e. if the normals of the planes are within tolerance, and the
+// base point of each plane lies within tolerance of the boundary line of the
+// other plane.
+//
+// N.B.: The base point of an ORCA plane is not unique; implementations may
+// choose any point along the boundary line.
+func Within(got hyperplane.HP, want hyperplane.HP, tolerance float64) bool {
+	return vector.Magnitude(vector.Sub(got.N(), want.N())) <= tolerance &&
+		math.Abs(vector.Dot(got.N(), vector.Sub(want.P(), got.P()))) <= tolerance &&
+		math.Abs(vector.Dot(want.N(), vector.Sub(got.P(), want.P()))) <= tolerance
+}
+
+// Minimize greedily simplifies the input parameters, while ensuring the
+// simplified parameters still fail the input check. Each parameter is
+// simplified in turn by replacing it with the first candidate which still
+// fails, in order of complexity, i.e.
+//
+//	0, ±1, and the parameter rounded to 0, 1, ..., 6 decimal digits.
+//
+// Passes are repeated until no parameter may be simplified further. Candidates
+// for which fails panics are rejected; the caller should also reject
+// candidates which are invalid inputs (e.g. a negative radius) by returning
+// false.
+//
+// The input slice is not modified.
+func Minimize(fs []float64, fails func(fs []float64) bool) []float64 {
+	ms := make([]float64, len(fs))
+	copy(ms, fs)
+
+	for changed := true; changed; {
+		changed = false
+		for i, f := range ms {
+			for _, g := range candidates(f) {
+				if g == f {
+					break
+				}
+				ms[i] = g
+				if try(fails, ms) {
+					changed = true
+					break
+				}
+				ms[i] = f
+			}
+		}
+	}
+	return ms
+}
+
+// candidates returns the list of simplifications of the input parameter, in
+// order of complexity. The last candidate is the parameter itself.
+func candidates(f float64) []float64 {
+	cs := []float64{0, math.Copysign(1, f)}
+	for i := 0; i <= digits; i++ {
+		s := math.Pow(10, float64(i))
+		cs = append(cs, math.Round(f*s)/s)
+	}
+	return append(cs, f)
+}
+
+func classify[T any, D comparable](f func(t T) D, t T) (d D, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	return f(t), true
+}
+
+func try(fails func(fs []float64) bool, fs []float64) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	return fails(fs)
+}
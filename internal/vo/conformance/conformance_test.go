@@ -0,0 +1,188 @@
+package conformance
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSample(t *testing.T) {
+	type config struct {
+		name    string
+		domains []int
+		n       int
+		m       int
+		succ    bool
+
+		// panics indicates some generated inputs must be reported
+		// as panicking.
+		panics bool
+	}
+
+	testConfigs := []config{
+		{name: "Covered", domains: []int{0, 1, 2}, n: 10, m: 100, succ: true},
+		{name: "Uncovered", domains: []int{0, 3}, n: 10, m: 100, succ: false},
+		{name: "Empty", domains: nil, n: 10, m: 100, succ: true},
+		// One in six generated inputs panics.
+		{name: "Panics", domains: []int{0, 1, 2}, n: 10, m: 6, succ: true, panics: true},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			ts, err := Sample(rand.New(rand.NewSource(0)), O[int, int]{
+				G: func(r *rand.Rand) int { return 99 - r.Intn(c.m) },
+				D: func(t int) int {
+					if t == 99 {
+						panic("degenerate input")
+					}
+					return t % 3
+				},
+				Domains: c.domains,
+				N:       c.n,
+			})
+			if succ := err == nil; succ != c.succ {
+				t.Fatalf("Sample() returned error %v, want success = %v", err, c.succ)
+			}
+			if !c.succ {
+				return
+			}
+			for _, d := range c.domains {
+				if got := len(ts.T[d]); got != c.n {
+					t.Errorf("len(Sample().T[%v]) = %v, want = %v", d, got, c.n)
+				}
+				for _, v := range ts.T[d] {
+					if v%3 != d || v == 99 {
+						t.Errorf("Sample().T[%v] contains unexpected input %v", d, v)
+					}
+				}
+			}
+			n := len(ts.Panics)
+			for _, d := range c.domains {
+				n += len(ts.T[d])
+			}
+			if n > ts.N {
+				t.Errorf("Sample() generated %v inputs, but returned %v", ts.N, n)
+			}
+			for _, v := range ts.Panics {
+				if v != 99 {
+					t.Errorf("Sample().Panics contains unexpected input %v", v)
+				}
+			}
+			if c.panics && len(ts.Panics) == 0 {
+				t.Errorf("len(Sample().Panics) = 0, want > 0")
+			}
+		})
+	}
+}
+
+func TestWithin(t *testing.T) {
+	type config struct {
+		name string
+		a    hyperplane.HP
+		b    hyperplane.HP
+		want bool
+	}
+
+	testConfigs := []config{
+		{
+			name: "Equal",
+			a:    *hyperplane.New(*vector.New(0, 1), *vector.New(0, 1)),
+			b:    *hyperplane.New(*vector.New(0, 1), *vector.New(0, 1)),
+			want: true,
+		},
+		{
+			name: "Equal/DifferentBase",
+			a:    *hyperplane.New(*vector.New(0, 1), *vector.New(0, 1)),
+			b:    *hyperplane.New(*vector.New(100, 1), *vector.New(0, 1)),
+			want: true,
+		},
+		{
+			name: "Offset",
+			a:    *hyperplane.New(*vector.New(0, 1), *vector.New(0, 1)),
+			b:    *hyperplane.New(*vector.New(0, 1.1), *vector.New(0, 1)),
+			want: false,
+		},
+		{
+			name: "AntiParallel",
+			a:    *hyperplane.New(*vector.New(0, 1), *vector.New(0, 1)),
+			b:    *hyperplane.New(*vector.New(0, 1), *vector.New(0, -1)),
+			want: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Within(c.a, c.b, 1e-5); got != c.want {
+				t.Errorf("Within() = %v, want = %v", got, c.want)
+			}
+			if got := Within(c.b, c.a, 1e-5); got != c.want {
+				t.Errorf("Within() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	type config struct {
+		name  string
+		fs    []float64
+		fails func(fs []float64) bool
+		want  []float64
+	}
+
+	testConfigs := []config{
+		{
+			name:  "Zero",
+			fs:    []float64{3.14159, -2.71828},
+			fails: func(fs []float64) bool { return fs[0] > 3 },
+			want:  []float64{3.1, 0},
+		},
+		{
+			name:  "Sign",
+			fs:    []float64{-2.71828},
+			fails: func(fs []float64) bool { return fs[0] < 0 },
+			want:  []float64{-1},
+		},
+		{
+			name:  "Coupled",
+			fs:    []float64{12.3456, 12.3457},
+			fails: func(fs []float64) bool { return fs[1]-fs[0] > 5e-5 },
+			want:  []float64{0, 1},
+		},
+		{
+			name: "Panic",
+			fs:   []float64{3.14159},
+			fails: func(fs []float64) bool {
+				if fs[0] < 3.141 {
+					panic("invalid input")
+				}
+				return true
+			},
+			want: []float64{3.142},
+		},
+		{
+			name:  "Irreducible",
+			fs:    []float64{3.14159},
+			fails: func(fs []float64) bool { return fs[0] == 3.14159 },
+			want:  []float64{3.14159},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			fs := make([]float64, len(c.fs))
+			copy(fs, c.fs)
+
+			got := Minimize(fs, c.fails)
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Errorf("Minimize() mismatch (-want +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(c.fs, fs); diff != "" {
+				t.Errorf("Minimize() modified the input (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
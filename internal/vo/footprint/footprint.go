@@ -0,0 +1,165 @@
+// Package footprint defines a velocity obstacle object which is constructed
+// from two agents with convex (non-circular) footprints.
+//
+// Given an agent A and an obstacle B with footprints F(A) and F(B), the two
+// agents collide when the relative position of B lies within the Minkowski
+// difference
+//
+//	M := F(B) ⊕ -F(A)
+//
+// translated by the relative position B.P() - A.P(). As footprints are rounded
+// convex polygons, M is also a rounded convex polygon. The truncated VO is then
+// constructed analogously to the circular case -- the VO consists of the two
+// tangent legs drawn from the origin to M, truncated by the scaled polygon
+// M / 𝜏. When both footprints are circles, M is a circle of radius
+// A.R() + B.R(), and we recover the usual truncated cone; when one footprint
+// is a capsule and the other is a circle, M is a capsule, which corresponds to
+// the wall VO in internal/geometry/2d/segment.
+package footprint
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/footprint"
+	"github.com/downflux/go-orca/agent/uncertainty"
+	"github.com/downflux/go-orca/internal/geometry/2d/polygon"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+)
+
+const (
+	minTau = 1e-3
+)
+
+type VO struct {
+	obstacle agent.A
+
+	weight opt.Weight
+	vopt   opt.VOpt
+}
+
+func New(obstacle agent.A, o opt.O) *VO {
+	if err := opt.Validate(o); err != nil {
+		panic(fmt.Sprintf("could not construct velocity obstacle: %v", err))
+	}
+
+	return &VO{
+		obstacle: obstacle,
+		weight:   o.Weight,
+		vopt:     o.VOpt,
+	}
+}
+
+// ORCA returns the half-plane of permissable velocities for the input agent.
+//
+// Agents which do not implement footprint.A are treated as circles of radius
+// A.R().
+func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP {
+	if tau < minTau {
+		panic("cannot construct ORCA constraint: invalid minimum lookahead timestep")
+	}
+
+	m := M(a, vo.obstacle)
+
+	// Account for the state estimation error of the agents by enlarging
+	// the rounded corners of M; see uncertainty.A for more details.
+	if e := uncertainty.R(a, vo.obstacle, tau); e > 0 {
+		m = *polygon.New(m.V(), m.R()+e)
+	}
+
+	u, n := preprocess(m, v(a, vo.obstacle), tau)
+	return *hyperplane.New(
+		vector.Add(vo.vopt(a), vector.Scale(float64(vo.weight), u)),
+		n,
+	)
+}
+
+// M returns the Minkowski difference of the two agent footprints, relative to
+// the agent position. The agents will collide if M contains the origin.
+func M(a agent.A, b agent.A) polygon.P {
+	return polygon.Translate(
+		vector.Sub(b.P(), a.P()),
+		polygon.Sum(f(b), polygon.Reflect(f(a))),
+	)
+}
+
+// preprocess calculates the vector u between the relative velocity v and the
+// closest point on the boundary of the truncated VO, along with the outward
+// normal n of the VO at that point.
+func preprocess(m polygon.P, v vector.V, tau float64) (vector.V, vector.V) {
+	tl, tr, ok := m.T()
+
+	// The agents are already overlapping; as in the circular case, we
+	// push the agents apart as fast as possible by considering the VO
+	// over a single minimal timestep.
+	if !ok {
+		b, n := polygon.Scale(1/minTau, m).B(v)
+		return vector.Sub(b, v), n
+	}
+
+	// The legs of the VO are the tangent lines from the origin to M. The VO
+	// lies to the right of the left leg, and to the left of the right leg.
+	type leg struct {
+		// d is the unit direction of the leg.
+		d vector.V
+		// n is the outward normal of the VO along the leg.
+		n vector.V
+		// s is the projected distance of v along the leg, relative to
+		// the scaled tangent point.
+		s float64
+	}
+
+	var ls []leg
+	for _, l := range []struct {
+		t vector.V
+		n func(d vector.V) vector.V
+	}{
+		{t: tl, n: func(d vector.V) vector.V { return *vector.New(-d.Y(), d.X()) }},
+		{t: tr, n: func(d vector.V) vector.V { return *vector.New(d.Y(), -d.X()) }},
+	} {
+		d := vector.Unit(l.t)
+		ls = append(ls, leg{
+			d: d,
+			n: l.n(d),
+			s: vector.Dot(vector.Sub(v, vector.Scale(1/tau, l.t)), d),
+		})
+	}
+
+	// If v projects onto both legs before the scaled tangent points, then
+	// v lies in the wedge region whose closest VO edge is the truncation
+	// boundary. This matches the circular domain check in the RVO2
+	// implementation.
+	if ls[0].s < 0 && ls[1].s < 0 {
+		b, n := polygon.Scale(1/tau, m).B(v)
+		return vector.Sub(b, v), n
+	}
+
+	// Otherwise, v is projected onto the closest leg line; as in RVO2, the
+	// leg is extended through the origin.
+	var l leg
+	switch {
+	case ls[0].s < 0:
+		l = ls[1]
+	case ls[1].s < 0:
+		l = ls[0]
+	case math.Abs(vector.Dot(v, ls[0].n)) <= math.Abs(vector.Dot(v, ls[1].n)):
+		l = ls[0]
+	default:
+		l = ls[1]
+	}
+	return vector.Sub(vector.Scale(vector.Dot(v, l.d), l.d), v), l.n
+}
+
+// f returns the footprint of the input agent, relative to the agent position.
+func f(a agent.A) polygon.P {
+	if a, ok := a.(footprint.A); ok {
+		return polygon.P(a.F())
+	}
+	return *polygon.New([]vector.V{*vector.New(0, 0)}, a.R())
+}
+
+// v calculates the relative velocity between the agent and the obstacle.
+func v(a agent.A, b agent.A) vector.V { return vector.Sub(a.V(), b.V()) }
@@ -0,0 +1,212 @@
+package footprint
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/line"
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/footprint"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/vo"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+	voagent "github.com/downflux/go-orca/internal/vo/agent"
+)
+
+var (
+	_ vo.VO       = VO{}
+	_ footprint.A = a{}
+)
+
+// a is an agent with an explicit footprint.
+type a struct {
+	agentimpl.A
+	f footprint.F
+}
+
+func (a a) F() footprint.F { return a.f }
+
+// rn returns a random int between [-100, 100).
+func rn() float64 { return rand.Float64()*200 - 100 }
+
+// ra returns an agent with randomized dimensions.
+func ra() agentimpl.A {
+	return *agentimpl.New(
+		agentimpl.O{
+			P: *vector.New(rn(), rn()),
+			V: *vector.New(rn(), rn()),
+			R: math.Abs(rn()),
+		},
+	)
+}
+
+// TestConformance checks that the footprint VO for two circular footprints
+// matches the default circular VO.
+//
+// N.B.: The circular VO projects v onto a truncation circle with the unscaled
+// combined radius, whereas the footprint VO projects onto the scaled polygon
+// M / 𝜏. The two constructions coincide when 𝜏 = 1.
+func TestConformance(t *testing.T) {
+	const n = 1000
+
+	type config struct {
+		name     string
+		agent    agentimpl.A
+		obstacle agentimpl.A
+		tau      float64
+	}
+
+	testConfigs := []config{
+		{
+			name:     "SimpleCase",
+			agent:    *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 0), R: 1}),
+			obstacle: *agentimpl.New(agentimpl.O{P: *vector.New(0, 5), V: *vector.New(1, -1), R: 2}),
+			tau:      1,
+		},
+		{
+			name:     "Collision",
+			agent:    *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 0), R: 1}),
+			obstacle: *agentimpl.New(agentimpl.O{P: *vector.New(0, 2), V: *vector.New(1, -1), R: 2}),
+			tau:      1,
+		},
+	}
+	for i := 0; i < n; i++ {
+		testConfigs = append(testConfigs, config{
+			name:     fmt.Sprintf("Random-%v", i),
+			agent:    ra(),
+			obstacle: ra(),
+			tau:      1,
+		})
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			o := opt.O{
+				Weight: opt.WeightEqual,
+				VOpt:   opt.VOptV,
+			}
+			got := New(
+				a{A: c.obstacle, f: *footprint.NewCircle(c.obstacle.R())},
+				o,
+			).ORCA(
+				a{A: c.agent, f: *footprint.NewCircle(c.agent.R())},
+				c.tau,
+			)
+			want := voagent.New(c.obstacle, o).ORCA(c.agent, c.tau)
+
+			if !hyperplane.WithinEpsilon(got, want, epsilon.Absolute(1e-5)) {
+				t.Errorf("ORCA() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
+
+func TestORCA(t *testing.T) {
+	type config struct {
+		name     string
+		agent    agent.A
+		obstacle agent.A
+		tau      float64
+
+		// clear indicates the current agent velocity should already
+		// be feasible; otherwise, the constructed ORCA plane is
+		// compared against want.
+		clear bool
+		want  hyperplane.HP
+	}
+
+	// capsule is a horizontal capsule spanning (-10, 0) to (10, 0), which
+	// is much longer than its bounding circle would suggest.
+	capsule := *footprint.NewCapsule(
+		*segment.New(
+			*line.New(
+				*vector.New(-10, 0),
+				*vector.New(1, 0),
+			),
+			0,
+			20,
+		),
+		1,
+	)
+
+	testConfigs := []config{
+		// The agent is moving directly towards the long side of the
+		// capsule, and should be pushed away along the capsule normal.
+		{
+			name: "Capsule/Side",
+			agent: a{
+				A: *agentimpl.New(agentimpl.O{
+					P: *vector.New(0, 5),
+					V: *vector.New(0, -4.5),
+					R: 1,
+				}),
+				f: *footprint.NewCircle(1),
+			},
+			obstacle: a{
+				A: *agentimpl.New(agentimpl.O{
+					P: *vector.New(0, 0),
+					V: *vector.New(0, 0),
+					R: 11,
+				}),
+				f: capsule,
+			},
+			tau: 1,
+			// The truncation boundary of the VO is the horizontal
+			// line y = -3.
+			want: *hyperplane.New(
+				*vector.New(0, -3),
+				*vector.New(0, 1),
+			),
+		},
+		// The agent is beyond the end of the capsule, and moving
+		// parallel to the capsule -- the bounding circle would have
+		// generated a constraint here, but the capsule VO should not
+		// constrain the current velocity.
+		{
+			name: "Capsule/Clear",
+			agent: a{
+				A: *agentimpl.New(agentimpl.O{
+					P: *vector.New(15, 5),
+					V: *vector.New(1, 0),
+					R: 1,
+				}),
+				f: *footprint.NewCircle(1),
+			},
+			obstacle: a{
+				A: *agentimpl.New(agentimpl.O{
+					P: *vector.New(0, 0),
+					V: *vector.New(0, 0),
+					R: 11,
+				}),
+				f: capsule,
+			},
+			tau:   1,
+			clear: true,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got := New(c.obstacle, opt.O{
+				Weight: opt.WeightAll,
+				VOpt:   opt.VOptV,
+			}).ORCA(c.agent, c.tau)
+			if c.clear {
+				if !got.In(c.agent.V()) {
+					t.Errorf("ORCA().In(%v) = false, want = true", c.agent.V())
+				}
+				return
+			}
+			if !hyperplane.Within(got, c.want) {
+				t.Errorf("ORCA() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
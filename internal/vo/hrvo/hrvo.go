@@ -0,0 +1,141 @@
+// Package hrvo defines a hybrid reciprocal velocity obstacle object which is
+// constructed from two agents.
+//
+// The hybrid reciprocal VO (HRVO) of Snape et al. (2011) is a VO whose apex
+// lies between the apex of the usual VO (i.e. the obstacle velocity) and the
+// apex of the reciprocal VO (RVO, i.e. the average of the two agent
+// velocities). Specifically, if the current relative velocity of the agent
+// lies to the left of the centerline of the RVO, i.e. the agent is already
+// passing the obstacle on the left, the apex of the HRVO is the intersection of
+// the right leg of the VO with the left leg of the RVO, and vice versa. This
+// enlarges the VO on the side the agent is not passing on, and therefore biases
+// both agents towards passing each other on the same (consistent) side.
+//
+// As with ORCA, we linearize the HRVO into a single half-plane by projecting
+// the agent velocity onto the closest edge of the (truncated) HRVO, so that the
+// constraint may be passed into the same LP solver.
+package hrvo
+
+import (
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/hypersphere"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/internal/geometry/2d/cone"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+
+	voagent "github.com/downflux/go-orca/internal/vo/agent"
+)
+
+type O struct {
+	// Preferred chooses the passing side from the preferred velocities of
+	// the agents instead of the current velocities, as per Snape et al.
+	// (2011). This may be used to avoid the passing side oscillating when
+	// the current velocities lie close to the centerline of the RVO, at
+	// the cost of ignoring the side the agents are already passing on.
+	Preferred bool
+}
+
+type VO struct {
+	obstacle agent.A
+	o        O
+}
+
+func New(obstacle agent.A, o O) *VO {
+	return &VO{
+		obstacle: obstacle,
+		o:        o,
+	}
+}
+
+// ORCA returns the half-plane of permissable velocities for the input agent.
+//
+// If the two agents are already colliding, the HRVO is not defined, and we
+// fall back to the usual reciprocal ORCA plane, which pushes the agents apart.
+func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP {
+	x, ok := apex(a, vo.obstacle, vo.o)
+	if !ok {
+		return voagent.New(vo.obstacle, opt.O{
+			Weight: opt.WeightEqual,
+			VOpt:   opt.VOptV,
+		}).ORCA(a, tau)
+	}
+
+	// The HRVO is a VO which is translated by the apex of the HRVO, which
+	// may be expressed as the VO of an obstacle which is moving at the
+	// apex velocity. Since the apex already accounts for the reciprocity
+	// between the agents, the input agent takes full responsibility for
+	// avoiding this (shifted) obstacle.
+	return voagent.New(
+		o{A: vo.obstacle, v: vector.Add(vo.obstacle.V(), x)},
+		opt.O{
+			Weight: opt.WeightAll,
+			VOpt:   opt.VOptV,
+		},
+	).ORCA(a, tau)
+}
+
+// apex returns the apex of the HRVO, relative to the obstacle velocity (i.e.
+// the apex of the VO). apex returns false if the agents are colliding.
+func apex(a agent.A, b agent.A, o O) (vector.V, bool) {
+	p := vector.Sub(b.P(), a.P())
+	c, err := cone.New(*hypersphere.New(p, a.R()+b.R()))
+	if err != nil {
+		return vector.V{}, false
+	}
+
+	// v is the relative velocity between the agents; the apex of the RVO
+	// lies at v / 2.
+	v := vector.Sub(a.V(), b.V())
+
+	// l and r are the unit directions of the left and right legs of the
+	// VO, pointing away from the origin.
+	//
+	// N.B.: The right leg of the cone is represented anti-parallel to the
+	// orientation.
+	l := vector.Unit(c.L().D())
+	r := vector.Unit(vector.Scale(-1, c.R().D()))
+
+	// d is the sine of the full opening angle of the VO, and is
+	// numerically unstable when the legs are (nearly) parallel, i.e. when
+	// the agents are very far apart. In this case, the HRVO is
+	// indistinguishable from the RVO.
+	d := vector.Determinant(r, l)
+	if epsilon.Within(d, 0) {
+		return vector.Scale(0.5, v), true
+	}
+
+	// The centerline of the RVO passes through the apex of the RVO, and is
+	// parallel to the relative position between the agents. The side of
+	// the centerline on which the relative velocity lies is then given by
+	//
+	//   |p x (v - v / 2)| = |p x v| / 2
+	w := v
+	if o.Preferred {
+		w = vector.Sub(a.T(), b.T())
+	}
+	if vector.Determinant(p, w) > 0 {
+		// The apex is the intersection of the right leg of the VO and
+		// the left leg of the RVO, i.e.
+		//
+		//   s * r = v / 2 + k * l
+		return vector.Scale(0.5*vector.Determinant(v, l)/d, r), true
+	}
+	// The apex is the intersection of the left leg of the VO and the right
+	// leg of the RVO, i.e.
+	//
+	//   t * l = v / 2 + k * r
+	return vector.Scale(0.5*vector.Determinant(r, v)/d, l), true
+}
+
+// o is an obstacle whose velocity is overridden by the HRVO apex.
+type o struct {
+	agent.A
+	v vector.V
+}
+
+func (o o) V() vector.V { return o.v }
+
+// Unwrap returns the underlying obstacle.
+func (o o) Unwrap() agent.A { return o.A }
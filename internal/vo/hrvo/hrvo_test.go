@@ -0,0 +1,179 @@
+package hrvo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/vo"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+	voagent "github.com/downflux/go-orca/internal/vo/agent"
+)
+
+var (
+	_ vo.VO = VO{}
+)
+
+func TestApex(t *testing.T) {
+	type config struct {
+		name     string
+		agent    agentimpl.A
+		obstacle agentimpl.A
+		o        O
+		success  bool
+		want     vector.V
+	}
+
+	testConfigs := []config{
+		// The VO has an opening angle of π / 2, with the left leg
+		// pointing along (-1, 1) and the right leg along (1, 1). The
+		// agent is passing on the left, and therefore the apex lies on
+		// the right leg of the VO, regardless of the preferred
+		// velocity.
+		{
+			name: "Left",
+			agent: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 0),
+				V: *vector.New(-1, 2),
+				T: *vector.New(1, 1),
+				R: math.Sqrt2 / 2,
+			}),
+			obstacle: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 2),
+				V: *vector.New(0, 0),
+				T: *vector.New(0, 0),
+				R: math.Sqrt2 / 2,
+			}),
+			success: true,
+			want:    *vector.New(0.25, 0.25),
+		},
+		{
+			name: "Right",
+			agent: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 0),
+				V: *vector.New(1, 2),
+				T: *vector.New(-1, 1),
+				R: math.Sqrt2 / 2,
+			}),
+			obstacle: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 2),
+				V: *vector.New(0, 0),
+				T: *vector.New(0, 0),
+				R: math.Sqrt2 / 2,
+			}),
+			success: true,
+			want:    *vector.New(-0.25, 0.25),
+		},
+		// The agent prefers to pass on the left, and therefore the apex
+		// lies on the right leg of the VO, regardless of the current
+		// velocity.
+		{
+			name: "Left/Preferred",
+			agent: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 0),
+				V: *vector.New(1, 2),
+				T: *vector.New(-1, 1),
+				R: math.Sqrt2 / 2,
+			}),
+			obstacle: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 2),
+				V: *vector.New(0, 0),
+				T: *vector.New(0, 0),
+				R: math.Sqrt2 / 2,
+			}),
+			o:       O{Preferred: true},
+			success: true,
+			want:    *vector.New(0.75, 0.75),
+		},
+		{
+			name: "Right/Preferred",
+			agent: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 0),
+				V: *vector.New(-1, 2),
+				T: *vector.New(1, 1),
+				R: math.Sqrt2 / 2,
+			}),
+			obstacle: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 2),
+				V: *vector.New(0, 0),
+				T: *vector.New(0, 0),
+				R: math.Sqrt2 / 2,
+			}),
+			o:       O{Preferred: true},
+			success: true,
+			want:    *vector.New(-0.75, 0.75),
+		},
+		{
+			name: "Collision",
+			agent: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 0),
+				R: 1,
+			}),
+			obstacle: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 1),
+				R: 1,
+			}),
+			success: false,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := apex(c.agent, c.obstacle, c.o)
+			if ok != c.success {
+				t.Fatalf("apex() = _, %v, want = _, %v", ok, c.success)
+			}
+			if ok && !vector.Within(got, c.want) {
+				t.Errorf("apex() = %v, _, want = %v, _", got, c.want)
+			}
+		})
+	}
+}
+
+func TestORCA(t *testing.T) {
+	t.Run("Collision", func(t *testing.T) {
+		a := *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 0), R: 1})
+		b := *agentimpl.New(agentimpl.O{P: *vector.New(0, 1), V: *vector.New(1, -1), R: 1})
+
+		want := voagent.New(b, opt.O{
+			Weight: opt.WeightEqual,
+			VOpt:   opt.VOptV,
+		}).ORCA(a, 1)
+		if got := New(b, O{}).ORCA(a, 1); !hyperplane.Within(got, want) {
+			t.Errorf("ORCA() = %v, want = %v", got, want)
+		}
+	})
+
+	// Two agents heading directly towards one another should both be
+	// pushed to pass on the same side (here, their respective right-hand
+	// sides), rather than choosing mirror-image velocities.
+	t.Run("HeadOn", func(t *testing.T) {
+		a := *agentimpl.New(agentimpl.O{
+			P: *vector.New(0, 0),
+			V: *vector.New(1, 0),
+			T: *vector.New(1, 0),
+			R: 1,
+		})
+		b := *agentimpl.New(agentimpl.O{
+			P: *vector.New(4, 0),
+			V: *vector.New(-1, 0),
+			T: *vector.New(-1, 0),
+			R: 1,
+		})
+
+		ha := New(b, O{}).ORCA(a, 10)
+		hb := New(a, O{}).ORCA(b, 10)
+
+		// The right-hand side of the agent is the direction of travel
+		// rotated clockwise.
+		if n := ha.N(); n.Y() >= 0 {
+			t.Errorf("ORCA().N() = %v, want a normal pointing towards -y", n)
+		}
+		if n := hb.N(); n.Y() <= 0 {
+			t.Errorf("ORCA().N() = %v, want a normal pointing towards +y", n)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+// Package obstacle defines a velocity obstacle object which is constructed from
+// a static circular obstacle.
+//
+// As the obstacle does not move, the agent takes full responsibility for
+// avoiding the obstacle (i.e. opt.WeightAll). Per van den Berg et al. (2011),
+// the optimization velocity for static obstacles is the 0-vector (i.e.
+// opt.VOptZero), and u is the vector from the optimization velocity to the
+// closest point on the boundary of the truncated VO. Because the closest point
+// to the origin always lies on the truncation circle, the ORCA plane may be
+// calculated in closed form -- given the relative position p and the combined
+// radius r of the agent and the obstacle,
+//
+//	u = (||p|| - r) / 𝜏 p̂
+//	n = -p̂
+//
+// That is, the agent may not move towards the obstacle faster than it would
+// take to reach the obstacle in 𝜏.
+package obstacle
+
+import (
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/obstacle"
+)
+
+const (
+	minTau = 1e-3
+)
+
+type VO struct {
+	obstacle obstacle.O
+}
+
+func New(obstacle obstacle.O) *VO {
+	return &VO{
+		obstacle: obstacle,
+	}
+}
+
+// ORCA returns the half-plane of permissable velocities for the input agent.
+func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP {
+	if tau < minTau {
+		panic("cannot construct ORCA constraint: invalid minimum lookahead timestep")
+	}
+
+	p := vector.Sub(vo.obstacle.P(), a.P())
+	r := a.R() + vo.obstacle.R()
+
+	// If the agent is already overlapping the obstacle, we push the agent
+	// out of the obstacle as quickly as possible by considering the VO
+	// over a single minimal timestep. Note that u = 0 at the boundary of
+	// the two cases.
+	d := vector.Magnitude(p)
+	if d < r {
+		tau = minTau
+	}
+
+	// The agent lies directly on the center of the obstacle; we choose an
+	// arbitrary direction to push the agent out.
+	n := *vector.New(-1, 0)
+	if !epsilon.Within(d, 0) {
+		n = vector.Unit(vector.Scale(-1, p))
+	}
+
+	u := vector.Scale(-(d-r)/tau, n)
+	return *hyperplane.New(
+		vector.Add(opt.VOptZero(a), vector.Scale(float64(opt.WeightAll), u)),
+		n,
+	)
+}
@@ -0,0 +1,105 @@
+package obstacle
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/obstacle"
+	"github.com/downflux/go-orca/vo"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+	voagent "github.com/downflux/go-orca/internal/vo/agent"
+)
+
+var (
+	_ vo.VO      = VO{}
+	_ obstacle.O = o{}
+)
+
+type o struct {
+	p vector.V
+	r float64
+}
+
+func (o o) P() vector.V { return o.p }
+func (o o) R() float64  { return o.r }
+
+func rn() float64 { return rand.Float64()*200 - 100 }
+
+func TestORCA(t *testing.T) {
+	type config struct {
+		name     string
+		agent    agentimpl.A
+		obstacle o
+		tau      float64
+		want     hyperplane.HP
+	}
+
+	testConfigs := []config{
+		{
+			name:     "Clear",
+			agent:    *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 10), R: 1}),
+			obstacle: o{p: *vector.New(0, 5), r: 1},
+			tau:      1,
+			want:     *hyperplane.New(*vector.New(0, 3), *vector.New(0, -1)),
+		},
+		{
+			name:     "Clear/Scaled",
+			agent:    *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 10), R: 1}),
+			obstacle: o{p: *vector.New(0, 5), r: 1},
+			tau:      2,
+			want:     *hyperplane.New(*vector.New(0, 1.5), *vector.New(0, -1)),
+		},
+		{
+			name:     "Collision",
+			agent:    *agentimpl.New(agentimpl.O{P: *vector.New(0, 0), V: *vector.New(0, 10), R: 1}),
+			obstacle: o{p: *vector.New(0, 1), r: 1},
+			tau:      1,
+			want:     *hyperplane.New(*vector.New(0, -1000), *vector.New(0, -1)),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := New(c.obstacle).ORCA(c.agent, c.tau); !hyperplane.Within(got, c.want) {
+				t.Errorf("ORCA() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestConformance checks that the obstacle VO matches the VO of a stationary
+// agent, where the input agent takes full responsibility for avoiding the
+// obstacle, and u is calculated from the 0-vector.
+//
+// N.B.: The agent VO projects onto a truncation circle with the unscaled
+// combined radius; the two constructions coincide when 𝜏 = 1.
+func TestConformance(t *testing.T) {
+	const n = 1000
+
+	for i := 0; i < n; i++ {
+		a := *agentimpl.New(agentimpl.O{
+			P: *vector.New(rn(), rn()),
+			V: *vector.New(0, 0),
+			R: math.Abs(rn()),
+		})
+		b := o{p: *vector.New(rn(), rn()), r: math.Abs(rn())}
+
+		t.Run(fmt.Sprintf("Random-%v", i), func(t *testing.T) {
+			got := New(b).ORCA(a, 1)
+			want := voagent.New(
+				*agentimpl.New(agentimpl.O{P: b.P(), V: *vector.New(0, 0), R: b.R()}),
+				opt.O{Weight: opt.WeightAll, VOpt: opt.VOptZero},
+			).ORCA(a, 1)
+			if !hyperplane.WithinEpsilon(got, want, epsilon.Absolute(1e-5)) {
+				t.Errorf("ORCA() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
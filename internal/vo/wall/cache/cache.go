@@ -1,7 +1,9 @@
 // Package cache implements the VO for a wall segment obstacle.
 //
-// TODO(minkezhang): Ensure VO returned is continuous at the regional
-// boundaries.
+// The ORCA plane returned is continuous in the agent velocity and position
+// across the non-collision domains. Note that the plane is not continuous as the
+// agent transitions into a physical collision with the wall, as the agent is
+// instead pushed directly out of the wall.
 package cache
 
 import (
@@ -13,6 +15,7 @@ import (
 	"github.com/downflux/go-geometry/2d/vector"
 	"github.com/downflux/go-geometry/epsilon"
 	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/internal/geometry/2d/predicate"
 	"github.com/downflux/go-orca/internal/vo/agent/opt"
 	"github.com/downflux/go-orca/internal/vo/wall/cache/domain"
 
@@ -29,212 +32,244 @@ type C struct {
 	// segment represents the physical line segment of the obstacle.
 	segment segment.S
 
+	// radius is the radius of the obstacle, i.e. the obstacle is the
+	// capsule of points within radius of the segment.
+	radius float64
+
 	agent agent.A
 	tau   float64
+
+	// robust indicates the collision domains should be classified with
+	// exact geometric predicates.
+	robust bool
 }
 
-func New(s segment.S, a agent.A, tau float64) *C {
+func New(s segment.S, r float64, a agent.A, tau float64) *C {
 	return &C{
 		segment: s,
+		radius:  r,
 		agent:   a,
 		tau:     tau,
 	}
 }
 
-func (c C) orca() (domain.D, hyperplane.HP) {
-	// Per van den Berg et al. (2011), we expect VOpt to be
-	// the 0-vector, and that u lies directly on the tangent
-	// plane (i.e. opt.WeightNone). This means the agent
-	// will not attempt to avoid collisions with the wall
-	// unless this will result in a collision within the
-	// next timestep.
-	o := opt.O{
-		Weight: opt.WeightNone,
-		VOpt:   opt.VOptZero,
-	}
+// NewRobust constructs a wall VO which classifies the collision domains with
+// exact geometric predicates, and which falls back to the collision ORCA plane
+// instead of panicking if the agent lies so close to the obstacle that the
+// truncated VO is degenerate.
+func NewRobust(s segment.S, r float64, a agent.A, tau float64) *C {
+	c := New(s, r, a, tau)
+	c.robust = true
+	return c
+}
 
+func (c C) orca() (domain.D, hyperplane.HP) {
 	// t is the projected parametric value along the extended line. We need
 	// to detect the case where t extends beyond the segment itself, and
 	// seg.T() truncates at the segment endpoints.
 	t := c.segment.L().T(c.agent.P())
 
-	// Agent physically collides with the semicircle on the left side of the
-	// line segment.
-	//
-	// A physical collision means that the agent overlaps the segment --
-	// becasue of computational limits, we split the check for
-	//
-	//   || c.segment.L().L(s.TMin()) - c.agent.P() || <= c.agent.R()
-	//
-	// into a strict inequality check and a float equality check (i.e.
-	// epsilon.Within()). If we do not do this check, the VO segment
-	// constructor may raise an unexpected error.
-	if p := vector.Magnitude(c.P(c.segment.TMin())); t <= c.segment.TMin() && (p < c.agent.R() || epsilon.Within(p, c.agent.R())) {
-		return domain.CollisionLeft, voagent.New(
-			agentimpl.New(
-				agentimpl.O{
-					P: c.segment.L().L(c.segment.TMin()),
-					V: *vector.New(0, 0),
-				},
-			),
-			o,
-		).ORCA(c.agent, c.tau)
-	}
-
-	// Agent physically collides with the semicircle on the right side of
-	// the line segment.
-	if p := vector.Magnitude(c.P(c.segment.TMax())); t >= c.segment.TMax() && (p < c.agent.R() || epsilon.Within(p, c.agent.R())) {
-		return domain.CollisionRight, voagent.New(
-			agentimpl.New(
-				agentimpl.O{
-					P: c.segment.L().L(c.segment.TMax()),
-					V: *vector.New(0, 0),
-				},
-			),
-			o,
-		).ORCA(c.agent, c.tau)
-	}
-
-	// d is perpendicular distance between the agent and the line.
-	d := c.segment.L().Distance(c.agent.P())
-
-	// Agent physically collides wth the line segment itself.
-	if (c.segment.TMin() <= t && t <= c.segment.TMax()) && (d < c.agent.R() || epsilon.Within(d, c.agent.R())) {
-		n := vector.Unit(
-			vector.Sub(
-				c.agent.P(),
-				c.segment.L().L(c.segment.T(c.agent.P())),
-			),
-		)
-		return domain.CollisionLine, *hyperplane.New(opt.VOptZero(c.agent), n)
+	if dm, ok := c.collision(t); ok {
+		return dm, c.push(dm, t)
 	}
 
 	// Construct a truncated line segment obstacle in v-space (i.e. where
 	// the absolute position does not matter anymore), scaled.
-	s := *vosegment.New(c.S(), *vector.New(0, 0), c.agent.R()/c.tau)
+	s, err := vosegment.New(c.S(), *vector.New(0, 0), c.R()/c.tau)
+	if err != nil {
+		if !c.robust {
+			panic(err)
+		}
+
+		// The agent lies (nearly) on the boundary of the obstacle,
+		// e.g. the agent is just outside the obstacle, but the scaled
+		// VO rounds to a degenerate cone; we treat this case as a
+		// collision with the closest part of the obstacle.
+		dm := domain.CollisionLine
+		if t < c.segment.TMin() {
+			dm = domain.CollisionLeft
+		} else if t > c.segment.TMax() {
+			dm = domain.CollisionRight
+		}
+		return dm, c.push(dm, t)
+	}
 
 	// If the agent does not physically collide with the obstacle in
 	// p-space, we need to determine if the agent will collide with the line
 	// in the future -- that is, if the agent and line obstacles will
-	// collide in v-space. We can split the VO into five separate domains,
-	// per the official RVO2 implementation, as follows
+	// collide in v-space.
 	//
-	//    \     |     /
-	//   L \ 2 /3\ 4 / R
-	//   1  \ /___\ /  5
-	//       |  S  |
-	//       |  6  |
+	// The truncated VO is the Minkowski sum of a disc of radius R / 𝜏 (where
+	// R is the combined radius of the agent and the obstacle) and
+	// the convex region K bounded by the scaled segment S and the two rays
+	// L', R' which originate at the ends of S and run parallel to the
+	// tangent legs of the VO
 	//
-	// Here, L, R are directed in the same direction as the tangential lines
-	// of the VO, but originate from the bottom line segment S, instead of
-	// at the tangential points. We use this construction to define regions
-	// 2 and 4, which are points in v-space that are closer to L and R than
-	// S. Region 6 is defined by convenience -- we remember that for the
-	// perpendicular bounds for this region, the generated normal to the VO
-	// is just the normal of the line segment itself.
+	//    \ L'      / R'
+	//     \   K   /
+	//      \_____/
+	//         S
 	//
-	// Note that the boundary lines opposite L and R, defining regions 2 and
-	// 4, respectively, bisects S.
+	// The ORCA plane is tangent to the VO at the point on the VO boundary
+	// closest to v. If q is the point on the boundary of K closest to v,
+	// then this point is q + (R / 𝜏)n, where n is the outward normal of K
+	// at q, i.e.
 	//
-	// We are modifying the six regions above to a slightly altered version
-	// for ease of calculations
+	//   n = (v - q) / || v - q ||   if v lies outside K, and
+	//   n = the outward normal of the closest edge of K otherwise.
 	//
-	//    \1 |2 | 4| 5/
-	//   L \ | /3\ | / R
-	//   1  \|/___\|/  5
-	//       |  S  |
-	//       |  6  |
+	// Because K is convex, the closest point q varies continuously with v
+	// outside of K, and the normal n does not flip as v crosses the
+	// boundary of K. The domain reports which part of the boundary of K
+	// contains q; unlike the official RVO2 implementation, the domain
+	// boundaries are therefore not discontinuities of the VO.
 	//
-	// These regions are the ones mentioned hereafter and in the tests.
+	// N.B.: Inside K, the closest edge may still switch abruptly along the
+	// medial axis of K; this is inherent to the ORCA construction, and
+	// occurs only when v is deep inside the VO.
+	k := *newK(*s)
 
-	l := line.New(s.S().L().L(s.S().TMax()), s.L().D())
-	r := line.New(s.S().L().L(s.S().TMin()), s.R().D())
+	e, q, dm := k.closest(c.agent.V())
 
-	oblique := vector.Within(
-		s.S().L().L(s.S().TMin()),
-		s.S().L().L(s.S().TMax()),
+	m := e.n
+	if w := vector.Sub(c.agent.V(), q); !k.in(c.agent.V()) && !epsilon.Within(vector.Magnitude(w), 0) {
+		m = vector.Unit(w)
+	}
+
+	// If q lies in the interior of an edge, the tangent line of the VO
+	// is parallel to the edge, and we anchor the ORCA plane at the
+	// starting vertex of the edge instead, per the official RVO2
+	// implementation.
+	if dm == domain.Line || dm == domain.Left || dm == domain.Right {
+		q = e.p
+	}
+	return dm, *hyperplane.New(
+		vector.Add(q, vector.Scale(c.R()/c.tau, m)),
+		m,
 	)
-	t = (s.S().TMax() - s.S().TMin()) / 2
-	if !oblique {
-		t = s.S().L().T(c.agent.V())
+}
+
+// collision checks if the agent physically collides with the obstacle, given
+// the projected parametric value t of the agent position onto the obstacle
+// line, and returns the collision domain.
+//
+// Per van den Berg et al. (2011), we expect VOpt to be the 0-vector in the case
+// of a physical collision.
+func (c C) collision(t float64) (domain.D, bool) {
+	if c.robust {
+		return c.collisionRobust()
 	}
 
-	// We know that t is bounded between the min and max t-values of S by
-	// now. The official implementation uses w to calculate the distance to
-	// the line segments; however, we note that this distance may be
-	// visually represented by measuring the distance between v and the
-	// perpendicular distance to the segments, which can be reasoned by
-	// drawing a diagram; note that w is important for relating to the VO
-	// radius, but here, we are deferring the circular domain calculations
-	// to the cone VO objects themselves.
-	tl := l.T(c.agent.V())
-	tr := r.T(c.agent.V())
-
-	// S() is a segment which is directed from the right to the left. This
-	// is done to ensure a consistent orientation between L(), S(), and R().
-	// Because S() starts from the "right" side, we are flipping the t
-	// boundary check from the official RVO2 implementation.
-	if (t > s.S().TMax() && tl < 0) || (oblique && tl < 0 && tr > 0) {
-		w := vector.Sub(c.agent.V(), l.P())
-		return domain.LeftCircle, *hyperplane.New(
-			/* p = */ line.New(
-				l.P(), vector.Unit(w),
-			).L(c.agent.R()/c.tau),
-			/* n = */ vector.Unit(w),
-		)
+	// Agent physically collides with the semicircle on the left side of
+	// the line segment.
+	//
+	// A physical collision means that the agent overlaps the segment --
+	// becasue of computational limits, we split the check for
+	//
+	//   || c.segment.L().L(s.TMin()) - c.agent.P() || <= c.R()
+	//
+	// into a strict inequality check and a float equality check (i.e.
+	// epsilon.Within()).
+	if p := vector.Magnitude(c.P(c.segment.TMin())); t <= c.segment.TMin() && (p < c.R() || epsilon.Within(p, c.R())) {
+		return domain.CollisionLeft, true
 	}
-	if t < s.S().TMin() && tr > 0 {
-		w := vector.Sub(c.agent.V(), r.P())
-		return domain.RightCircle, *hyperplane.New(
-			line.New(
-				r.P(), vector.Unit(w),
-			).L(c.agent.R()/c.tau),
-			vector.Unit(w),
-		)
+
+	// Agent physically collides with the semicircle on the right side of
+	// the line segment.
+	if p := vector.Magnitude(c.P(c.segment.TMax())); t >= c.segment.TMax() && (p < c.R() || epsilon.Within(p, c.R())) {
+		return domain.CollisionRight, true
 	}
 
-	d = map[bool]float64{
-		true:  math.Inf(1),
-		false: s.S().L().Distance(c.agent.V()),
-	}[oblique || t < s.S().TMin() || t > s.S().TMax()]
-	dl := map[bool]float64{
-		true:  math.Inf(1),
-		false: l.Distance(c.agent.V()),
-	}[tl < 0]
-	dr := map[bool]float64{
-		true:  math.Inf(1),
-		false: r.Distance(c.agent.V()),
-	}[tr > 0]
-
-	// This check is for region 7 and parts of region 3 (specifically, the
-	// parts "under" region 3 bounded by the tl = 0 and tr = 0 normal lines.
-	if tl < 0 && tr > 0 || d <= dl && d <= dr {
-		w := vector.Sub(c.agent.V(), s.S().L().L(t))
-		return domain.Line, *hyperplane.New(
-			line.New(
-				r.P(), vector.Unit(w),
-			).L(c.agent.R()/c.tau),
-			vector.Unit(w),
-		)
+	// d is perpendicular distance between the agent and the line.
+	d := c.segment.L().Distance(c.agent.P())
+
+	// Agent physically collides wth the line segment itself.
+	if (c.segment.TMin() <= t && t <= c.segment.TMax()) && (d < c.R() || epsilon.Within(d, c.R())) {
+		return domain.CollisionLine, true
 	}
-	if dl <= dr {
-		w := vector.Sub(c.agent.V(), l.L(tl))
-		return domain.Left, *hyperplane.New(
-			line.New(
-				l.P(), vector.Unit(w),
-			).L(c.agent.R()/c.tau),
-			vector.Unit(w),
-		)
+	return 0, false
+}
 
+// collisionRobust is a variant of collision which uses exact geometric
+// predicates, i.e. the agent collides with the obstacle if and only if the
+// exact distance between the agent and the (rounded) segment endpoints is at
+// most R. The checks are therefore mutually consistent, e.g. an agent which
+// lies just beyond the end of the segment is never classified as colliding
+// with the segment line.
+func (c C) collisionRobust() (domain.D, bool) {
+	p := c.agent.P()
+	d := c.segment.L().D()
+	a, b := c.segment.L().L(c.segment.TMin()), c.segment.L().L(c.segment.TMax())
+
+	switch {
+	case predicate.Side(a, d, p) <= 0:
+		if predicate.InCircle(a, c.R(), p) >= 0 {
+			return domain.CollisionLeft, true
+		}
+	case predicate.Side(b, d, p) >= 0:
+		if predicate.InCircle(b, c.R(), p) >= 0 {
+			return domain.CollisionRight, true
+		}
+	default:
+		if predicate.InStrip(a, d, c.R(), p) >= 0 {
+			return domain.CollisionLine, true
+		}
 	}
+	return 0, false
+}
 
-	w := vector.Sub(c.agent.V(), r.L(tr))
-	return domain.Right, *hyperplane.New(
-		line.New(
-			r.P(), vector.Unit(w),
-		).L(c.agent.R()/c.tau),
-		vector.Unit(w),
-	)
+// push returns the ORCA plane for an agent which physically collides with the
+// obstacle in the input collision domain, given the projected parametric value
+// t of the agent position onto the obstacle line.
+//
+// If the agent collides with either end of the segment, the end is treated as a
+// stationary agent obstacle, and the agent is pushed out of the obstacle within
+// the minimum lookahead time, per the agent VO. Otherwise, the agent is pushed
+// directly away from the closest point on the segment.
+func (c C) push(dm domain.D, t float64) hyperplane.HP {
+	// Per van den Berg et al. (2011), we expect VOpt to be
+	// the 0-vector, and that u lies directly on the tangent
+	// plane (i.e. opt.WeightNone). This means the agent
+	// will not attempt to avoid collisions with the wall
+	// unless this will result in a collision within the
+	// next timestep.
+	o := opt.O{
+		Weight: opt.WeightNone,
+		VOpt:   opt.VOptZero,
+	}
+
+	switch dm {
+	case domain.CollisionLeft:
+		return voagent.New(
+			agentimpl.New(
+				agentimpl.O{
+					P: c.segment.L().L(c.segment.TMin()),
+					V: *vector.New(0, 0),
+					R: c.radius,
+				},
+			),
+			o,
+		).ORCA(c.agent, c.tau)
+	case domain.CollisionRight:
+		return voagent.New(
+			agentimpl.New(
+				agentimpl.O{
+					P: c.segment.L().L(c.segment.TMax()),
+					V: *vector.New(0, 0),
+					R: c.radius,
+				},
+			),
+			o,
+		).ORCA(c.agent, c.tau)
+	default:
+		return *hyperplane.New(
+			opt.VOptZero(c.agent),
+			n(
+				vector.Scale(-1, c.P(t)),
+				*vector.New(-c.segment.L().D().Y(), c.segment.L().D().X()),
+			),
+		)
+	}
 }
 
 // domain returns the domain in p-space of interaction between the velocity
@@ -257,6 +292,9 @@ func (c C) ORCA() hyperplane.HP {
 	return hp
 }
 
+// R returns the combined radius of the agent and the obstacle.
+func (c C) R() float64 { return c.agent.R() + c.radius }
+
 // S returns the characteristic line segment defining the velocity obstacle,
 // taking into account the time scalar 𝜏.
 func (c C) S() segment.S { return s(c.segment, c.agent, c.tau) }
@@ -299,3 +337,119 @@ func min(vs []float64) float64 {
 	}
 	return m
 }
+
+// n returns the unit vector of v, or the fallback unit vector f if v is the
+// 0-vector, e.g. if the agent lies directly on the segment.
+func n(v vector.V, f vector.V) vector.V {
+	if epsilon.Within(vector.Magnitude(v), 0) {
+		return vector.Unit(f)
+	}
+	return vector.Unit(v)
+}
+
+// e is an edge of the boundary of the region K, along with the domains
+// associated with the interior and the starting vertex of the edge.
+type e struct {
+	// p is the starting vertex of the edge.
+	p vector.V
+
+	// v is the direction of the edge; the edge spans p + tv for t in
+	// [0, 1] if bounded, and t ≥ 0 otherwise.
+	v       vector.V
+	bounded bool
+
+	// n is the outward unit normal of K along the edge.
+	n vector.V
+
+	// d is the domain of velocities which are closest to the interior of
+	// the edge, and c and f are the domains of velocities which are
+	// closest to the starting and final vertices (i.e. the rounded corners
+	// of the VO), respectively.
+	d domain.D
+	c domain.D
+	f domain.D
+}
+
+// k is the convex region of v-space which, when rounded by R / 𝜏, forms the
+// truncated wall VO.
+type k struct {
+	es []e
+}
+
+func newK(s vosegment.S) *k {
+	// S() is directed from the right to the left end of the truncated
+	// base, and L() and R() are the left and right tangent legs; note
+	// that R() is directed towards the origin.
+	pl := s.S().L().L(s.S().TMax())
+	pr := s.S().L().L(s.S().TMin())
+	dl := vector.Unit(s.L().D())
+	dr := vector.Unit(vector.Scale(-1, s.R().D()))
+
+	// c is a point which lies strictly within K, and is used to orient
+	// the edge normals.
+	c := vector.Add(
+		vector.Scale(0.5, vector.Add(pl, pr)),
+		vector.Add(dl, dr),
+	)
+
+	es := []e{
+		{p: pl, v: dl, d: domain.Left, c: domain.LeftCircle},
+		{p: pr, v: dr, d: domain.Right, c: domain.RightCircle},
+	}
+	// The segment VO is oblique if the far end of the segment is obscured
+	// by the near end, in which case K is a cone with a single vertex.
+	if !vector.Within(pl, pr) {
+		es = append([]e{
+			{p: pr, v: vector.Sub(pl, pr), bounded: true, d: domain.Line, c: domain.RightCircle, f: domain.LeftCircle},
+		}, es...)
+	}
+	for i := range es {
+		m := *vector.New(-es[i].v.Y(), es[i].v.X())
+		if vector.Dot(m, vector.Sub(c, es[i].p)) > 0 {
+			m = vector.Scale(-1, m)
+		}
+		es[i].n = vector.Unit(m)
+	}
+	return &k{es: es}
+}
+
+// in checks if the input vector lies within K.
+func (k k) in(v vector.V) bool {
+	for _, e := range k.es {
+		if vector.Dot(e.n, vector.Sub(v, e.p)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// closest returns the edge of K closest to the input vector, along with the
+// closest point on the edge and the domain of the input vector.
+//
+// Ties are broken in favor of the truncated base, and then in favor of points
+// which lie in the interior of an edge.
+func (k k) closest(v vector.V) (e, vector.V, domain.D) {
+	var f e
+	var q vector.V
+	var dm domain.D
+
+	clamped := false
+	d := math.Inf(1)
+	for _, g := range k.es {
+		t := vector.Dot(vector.Sub(v, g.p), g.v) / vector.SquaredMagnitude(g.v)
+
+		c, h := false, g.d
+		if t < 0 {
+			t, c, h = 0, true, g.c
+		}
+		if g.bounded && t > 1 {
+			t, c, h = 1, true, g.f
+		}
+
+		p := vector.Add(g.p, vector.Scale(t, g.v))
+		if m := vector.SquaredMagnitude(vector.Sub(v, p)); m < d || (m == d && clamped && !c) {
+			f, q, dm, clamped, d = g, p, h, c, m
+		}
+	}
+	return f, q, dm
+}
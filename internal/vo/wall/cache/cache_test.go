@@ -1,15 +1,22 @@
 package cache
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/downflux/go-geometry/2d/hyperplane"
 	"github.com/downflux/go-geometry/2d/line"
 	"github.com/downflux/go-geometry/2d/segment"
 	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
 	"github.com/downflux/go-orca/internal/agent"
+	"github.com/downflux/go-orca/internal/vo/conformance"
 	"github.com/downflux/go-orca/internal/vo/wall/cache/domain"
+
+	reference "github.com/downflux/go-orca/external/snape/RVO2/vo/wall"
+	vosegment "github.com/downflux/go-orca/internal/geometry/2d/segment"
 )
 
 const (
@@ -19,6 +26,7 @@ const (
 func cache(s segment.S, p vector.V, v vector.V) C {
 	return *New(
 		s,
+		/* r = */ 0,
 		*agent.New(
 			agent.O{
 				P: p,
@@ -118,7 +126,11 @@ func TestORCA(t *testing.T) {
 				// In the case that an agent's center overlaps
 				// the actual line segment, we want to ensure
 				// the agent moves away in a reasonable
-				// direction.
+				// direction. The optimization velocity of both
+				// the agent and the segment end is the zero
+				// vector, so the agent is pushed directly away
+				// from the end, regardless of its current
+				// velocity.
 				{
 					name: "Collision/Left",
 					c: cache(
@@ -128,7 +140,7 @@ func TestORCA(t *testing.T) {
 					),
 					want: *hyperplane.New(
 						*vector.New(0, 0),
-						*vector.New(-0.9999500037496875, 0.009999500037496866),
+						*vector.New(-1, 0),
 					),
 				},
 				{
@@ -140,7 +152,7 @@ func TestORCA(t *testing.T) {
 					),
 					want: *hyperplane.New(
 						*vector.New(0, 0),
-						*vector.New(0.9999500037496875, 0.009999500037496866),
+						*vector.New(1, 0),
 					),
 				},
 			}
@@ -526,3 +538,491 @@ func TestDomain(t *testing.T) {
 		})
 	}
 }
+
+// TestContinuity checks that the ORCA plane varies continuously with the agent
+// velocity and position across the domain boundaries.
+func TestContinuity(t *testing.T) {
+	const (
+		n   = 1000
+		eps = 1e-9
+		tol = 1e-4
+	)
+
+	r := rand.New(rand.NewSource(0))
+	rn := func() float64 { return 20*r.Float64() - 10 }
+
+	// within checks that the two planes are approximately equal, i.e.
+	// share a normal and the same boundary line.
+	within := func(a hyperplane.HP, b hyperplane.HP) bool {
+		return vector.WithinEpsilon(a.N(), b.N(), epsilon.Absolute(tol)) && epsilon.Absolute(tol).Within(
+			vector.Dot(a.N(), vector.Sub(b.P(), a.P())), 0)
+	}
+
+	t.Run("Velocity", func(t *testing.T) {
+		// seen tracks the domain boundaries that have been sampled.
+		seen := map[[2]domain.D]bool{}
+
+		for i := 0; i < n; i++ {
+			s := *segment.New(*line.New(*vector.New(rn(), rn()), *vector.New(rn(), rn())), 0, 1)
+			a := agent.O{P: *vector.New(rn(), rn()), R: 1 + r.Float64()}
+			tau := 0.5 + 2*r.Float64()
+
+			c := func(v vector.V) C {
+				a := a
+				a.V = v
+				return *New(s, 0, *agent.New(a), tau)
+			}
+			if d := c(*vector.New(0, 0)).domain(); d == domain.CollisionLeft || d == domain.CollisionRight || d == domain.CollisionLine {
+				continue
+			}
+
+			u, v := *vector.New(rn(), rn()), *vector.New(rn(), rn())
+			du, dv := c(u).domain(), c(v).domain()
+			if du == dv {
+				continue
+			}
+
+			// Bisect until u and v lie on either side of a domain
+			// boundary.
+			for vector.Magnitude(vector.Sub(u, v)) > eps {
+				m := vector.Scale(0.5, vector.Add(u, v))
+				if dm := c(m).domain(); dm == du {
+					u = m
+				} else {
+					v, dv = m, dm
+				}
+			}
+
+			// The closest edge of K may switch discontinuously on
+			// the medial axis of K.
+			w, err := vosegment.New(c(u).S(), *vector.New(0, 0), a.R/tau)
+			if err != nil {
+				t.Fatalf("New() = _, %v, want = _, nil", err)
+			}
+			k := *newK(*w)
+			if k.in(u) && k.in(v) {
+				continue
+			}
+
+			seen[[2]domain.D{du, dv}] = true
+			seen[[2]domain.D{dv, du}] = true
+			if got, want := c(u).ORCA(), c(v).ORCA(); !within(got, want) {
+				t.Errorf("ORCA() = %v, want = %v (%v -> %v)", got, want, du, dv)
+			}
+		}
+
+		for _, b := range [][2]domain.D{
+			{domain.Left, domain.LeftCircle},
+			{domain.LeftCircle, domain.Line},
+			{domain.Line, domain.RightCircle},
+			{domain.RightCircle, domain.Right},
+		} {
+			if !seen[b] {
+				t.Errorf("did not sample domain boundary %v -> %v", b[0], b[1])
+			}
+		}
+	})
+
+	// Check the ORCA plane does not flip as the velocity crosses the
+	// boundary of K, i.e. as the velocity enters deeper into the VO.
+	t.Run("Velocity/K", func(t *testing.T) {
+		for i := 0; i < n; i++ {
+			s := *segment.New(*line.New(*vector.New(rn(), rn()), *vector.New(rn(), rn())), 0, 1)
+			a := agent.O{P: *vector.New(rn(), rn()), R: 1 + r.Float64()}
+			tau := 0.5 + 2*r.Float64()
+
+			c := func(v vector.V) C {
+				a := a
+				a.V = v
+				return *New(s, 0, *agent.New(a), tau)
+			}
+			if d := c(*vector.New(0, 0)).domain(); d == domain.CollisionLeft || d == domain.CollisionRight || d == domain.CollisionLine {
+				continue
+			}
+
+			w, err := vosegment.New(c(*vector.New(0, 0)).S(), *vector.New(0, 0), a.R/tau)
+			if err != nil {
+				t.Fatalf("New() = _, %v, want = _, nil", err)
+			}
+			k := *newK(*w)
+			e := k.es[r.Intn(len(k.es))]
+			q := vector.Add(e.p, vector.Scale(r.Float64(), e.v))
+
+			u := vector.Add(q, vector.Scale(eps, e.n))
+			v := vector.Sub(q, vector.Scale(eps, e.n))
+			if _, p, _ := k.closest(v); !vector.WithinEpsilon(p, q, epsilon.Absolute(tol)) {
+				// q lies close to the medial axis of K.
+				continue
+			}
+			if got, want := c(u).ORCA(), c(v).ORCA(); !within(got, want) {
+				t.Errorf("ORCA() = %v, want = %v", got, want)
+			}
+		}
+	})
+
+	// Check the ORCA plane varies continuously with the agent position
+	// across every domain boundary, except as the agent enters a physical
+	// collision with the obstacle.
+	t.Run("Position", func(t *testing.T) {
+		collision := func(d domain.D) bool {
+			return d == domain.CollisionLeft || d == domain.CollisionRight || d == domain.CollisionLine
+		}
+
+		seen := map[[2]domain.D]bool{}
+
+		for i := 0; i < 10*n; i++ {
+			s := *segment.New(*line.New(*vector.New(rn(), rn()), *vector.New(rn(), rn())), 0, 1)
+			a := agent.O{V: *vector.New(rn(), rn()), R: 1 + r.Float64()}
+			tau := 0.5 + 2*r.Float64()
+
+			c := func(p vector.V) C {
+				a := a
+				a.P = p
+				return *New(s, 0, *agent.New(a), tau)
+			}
+
+			// Sample agent positions around the segment, including
+			// positions which overlap the segment.
+			g := func() vector.V {
+				return vector.Add(
+					s.L().L(2*r.Float64()-0.5),
+					vector.Scale(3*a.R*r.Float64(), vector.Unit(*vector.New(rn(), rn()))),
+				)
+			}
+			u, v := g(), g()
+			du, dv := c(u).domain(), c(v).domain()
+			if du == dv {
+				continue
+			}
+
+			for vector.Magnitude(vector.Sub(u, v)) > eps {
+				m := vector.Scale(0.5, vector.Add(u, v))
+				if dm := c(m).domain(); dm == du {
+					u = m
+				} else {
+					v, dv = m, dm
+				}
+			}
+
+			seen[[2]domain.D{du, dv}] = true
+			seen[[2]domain.D{dv, du}] = true
+
+			got, want := c(u).ORCA(), c(v).ORCA()
+			switch {
+			// The agent is pushed directly out of the obstacle
+			// once it enters a physical collision.
+			case collision(du) != collision(dv):
+				continue
+			// The ends of the segment push the agent out of the
+			// obstacle within the minimum lookahead time, and the
+			// normal therefore deviates from the normal of the
+			// segment by an angle of at most ~minTau ||v|| / d at
+			// the boundary, where d is the distance from the agent
+			// to the segment.
+			case collision(du):
+				e := tol + 2*minTau*vector.Magnitude(a.V)/s.L().Distance(u)
+				if !vector.WithinEpsilon(got.N(), want.N(), epsilon.Absolute(e)) || !vector.Within(got.P(), want.P()) {
+					t.Errorf("ORCA() = %v, want = %v (%v -> %v)", got, want, du, dv)
+				}
+			default:
+				w, err := vosegment.New(c(u).S(), *vector.New(0, 0), a.R/tau)
+				if err != nil {
+					t.Fatalf("New() = _, %v, want = _, nil", err)
+				}
+				// The closest edge of K may switch
+				// discontinuously on the medial axis of K.
+				if k := *newK(*w); k.in(a.V) {
+					continue
+				}
+				if !within(got, want) {
+					t.Errorf("ORCA() = %v, want = %v (%v -> %v)", got, want, du, dv)
+				}
+			}
+		}
+
+		for _, b := range [][2]domain.D{
+			{domain.Left, domain.LeftCircle},
+			{domain.LeftCircle, domain.Line},
+			{domain.Line, domain.RightCircle},
+			{domain.RightCircle, domain.Right},
+			{domain.CollisionLeft, domain.CollisionLine},
+			{domain.CollisionLine, domain.CollisionRight},
+		} {
+			if !seen[b] {
+				t.Errorf("did not sample domain boundary %v -> %v", b[0], b[1])
+			}
+		}
+	})
+}
+
+func TestRobust(t *testing.T) {
+	const n = 1000
+
+	r := rand.New(rand.NewSource(0))
+	rn := func() float64 { return 20*r.Float64() - 10 }
+
+	// robust constructs a robust wall VO for an agent with the input
+	// radius and position.
+	robust := func(s segment.S, radius float64, p vector.V) C {
+		return *NewRobust(s, 0, *agent.New(agent.O{
+			P: p,
+			R: radius,
+			V: *vector.New(rn(), rn()),
+		}), 1)
+	}
+
+	// A point agent and a zero-width wall do not define a valid truncated
+	// VO; the default implementation panics here.
+	t.Run("ZeroRadius", func(t *testing.T) {
+		s := *segment.New(*line.New(*vector.New(-1, 1), *vector.New(1, 0)), 0, 2)
+		c := robust(s, 0, *vector.New(0, 0))
+		if got, want := c.domain(), domain.CollisionLine; got != want {
+			t.Errorf("domain() = %v, want = %v", got, want)
+		}
+	})
+
+	// Agents which lie far away from the domain boundaries are classified
+	// the same way by the robust and the default predicates.
+	t.Run("Conformance", func(t *testing.T) {
+		for i := 0; i < n; i++ {
+			s := *segment.New(*line.New(*vector.New(rn(), rn()), *vector.New(rn(), rn())), 0, 1)
+			p := *vector.New(rn(), rn())
+
+			c := robust(s, 1, p)
+			d := c
+			d.robust = false
+
+			tol := 1e-6
+			if u := s.L().T(p); math.Abs(u-s.TMin()) < tol || math.Abs(u-s.TMax()) < tol {
+				continue
+			}
+			if math.Abs(vector.Magnitude(vector.Sub(s.L().L(s.T(p)), p))-c.R()) < tol {
+				continue
+			}
+			if got, want := c.domain(), d.domain(); got != want {
+				t.Errorf("domain() = %v, want = %v", got, want)
+			}
+		}
+	})
+
+	// Agents which lie (nearly) on the boundary of the obstacle never
+	// cause the VO construction to panic.
+	t.Run("Boundary", func(t *testing.T) {
+		for i := 0; i < n; i++ {
+			s := *segment.New(*line.New(*vector.New(rn(), rn()), *vector.New(rn(), rn())), 0, 1)
+
+			// Place the agent (nearly) exactly R away from the
+			// capsule skeleton.
+			var p vector.V
+			switch n := vector.Unit(*vector.New(-s.L().D().Y(), s.L().D().X())); r.Intn(3) {
+			case 0:
+				p = vector.Add(s.L().L(r.Float64()), n)
+			case 1:
+				u := vector.Unit(*vector.New(rn(), rn()))
+				if vector.Dot(u, s.L().D()) > 0 {
+					u = vector.Scale(-1, u)
+				}
+				p = vector.Add(s.L().L(s.TMin()), u)
+			default:
+				u := vector.Unit(*vector.New(rn(), rn()))
+				if vector.Dot(u, s.L().D()) < 0 {
+					u = vector.Scale(-1, u)
+				}
+				p = vector.Add(s.L().L(s.TMax()), u)
+			}
+
+			func() {
+				defer func() {
+					if err := recover(); err != nil {
+						t.Errorf("ORCA() panicked: %v", err)
+					}
+				}()
+				robust(s, 1, p).ORCA()
+			}()
+		}
+	})
+}
+
+// TestConformance is a randomized differential test which checks the ORCA
+// plane of the VO of a zero-width wall against the RVO2 reference
+// implementation across all domains.
+//
+// On failure, the mismatching input is minimized and printed as a config
+// literal which may be directly added to the manual test cases.
+// flipped reports if the reference plane want is the plane got with the normal
+// reversed, where v lies within the VO.
+//
+// The reference orients the ORCA plane towards v, and therefore flips the plane
+// when v lies within the VO, whereas the VO under test orients the plane away
+// from the VO. These inputs are known divergences from the reference.
+func flipped(got hyperplane.HP, want hyperplane.HP, v vector.V) bool {
+	return vector.Magnitude(vector.Add(vector.Unit(got.N()), vector.Unit(want.N()))) <= 1e-5 && !got.In(v)
+}
+
+func TestConformance(t *testing.T) {
+	const (
+		n   = 1000
+		tol = 1e-5
+	)
+
+	type config struct {
+		name    string
+		segment segment.S
+		agent   agent.A
+		tau     float64
+	}
+
+	testConfigs := []config{
+		{
+			name:    "Manual/Line",
+			segment: *segment.New(*line.New(*vector.New(-2, 2), *vector.New(1, 0)), 0, 4),
+			agent:   *agent.New(agent.O{P: *vector.New(0, 0), V: *vector.New(0, 4), R: 1}),
+			tau:     1,
+		},
+	}
+
+	// encode and decode convert between a config and the free parameters
+	// manipulated by conformance.Minimize.
+	encode := func(c config) []float64 {
+		return []float64{
+			c.segment.L().P().X(), c.segment.L().P().Y(), c.segment.L().D().X(), c.segment.L().D().Y(), c.segment.TMin(), c.segment.TMax(),
+			c.agent.P().X(), c.agent.P().Y(), c.agent.V().X(), c.agent.V().Y(), c.agent.R(),
+			c.tau,
+		}
+	}
+	decode := func(fs []float64) config {
+		return config{
+			segment: *segment.New(*line.New(*vector.New(fs[0], fs[1]), *vector.New(fs[2], fs[3])), fs[4], fs[5]),
+			agent:   *agent.New(agent.O{P: *vector.New(fs[6], fs[7]), V: *vector.New(fs[8], fs[9]), R: fs[10]}),
+			tau:     fs[11],
+		}
+	}
+	literal := func(c config) string {
+		return fmt.Sprintf(
+			"{\n\tname:    %q,\n\tsegment: *segment.New(*line.New(*vector.New(%v, %v), *vector.New(%v, %v)), %v, %v),\n\tagent:   *agent.New(agent.O{P: *vector.New(%v, %v), V: *vector.New(%v, %v), R: %v}),\n\ttau:     %v,\n},",
+			c.name,
+			c.segment.L().P().X(), c.segment.L().P().Y(), c.segment.L().D().X(), c.segment.L().D().Y(), c.segment.TMin(), c.segment.TMax(),
+			c.agent.P().X(), c.agent.P().Y(), c.agent.V().X(), c.agent.V().Y(), c.agent.R(),
+			c.tau)
+	}
+
+	// check returns the ORCA planes generated by the VO under test and
+	// the reference implementation, and if the planes match.
+	check := func(c config) (hyperplane.HP, hyperplane.HP, bool) {
+		// The VO pushes the agent out of the ends of the segment
+		// within the minimum lookahead time, and so the normal of the
+		// ORCA plane also depends on the agent velocity, whereas RVO2
+		// pushes the agent directly away from the end. The two agree
+		// for a stationary agent.
+		if d := New(c.segment, 0, c.agent, c.tau).domain(); d == domain.CollisionLeft || d == domain.CollisionRight {
+			c.agent = *agent.New(agent.O{P: c.agent.P(), V: *vector.New(0, 0), R: c.agent.R()})
+		}
+
+		got := New(c.segment, 0, c.agent, c.tau).ORCA()
+		want := reference.New(c.segment).ORCA(c.agent, c.tau)
+
+		// The tolerance is relative to the scale of the input
+		// velocities.
+		s := math.Max(1, math.Max(
+			vector.Magnitude(c.agent.V()),
+			c.segment.L().Distance(c.agent.P())/c.tau,
+		))
+		return got, want, conformance.Within(got, want, tol*s)
+	}
+
+	// g generates an agent near the segment, whose velocity points
+	// towards some point near the segment, offset by a vector w whose
+	// magnitude spans several orders of magnitude, which ensures all
+	// domains are covered.
+	g := func(r *rand.Rand) config {
+		u := func() vector.V {
+			theta := 2 * math.Pi * r.Float64()
+			return *vector.New(math.Cos(theta), math.Sin(theta))
+		}
+		// The reference implementation assumes the segment is
+		// parameterized from TMin = 0.
+		tmin := 0.0
+		tmax := 2*r.Float64() + 0.1
+		s := *segment.New(
+			*line.New(
+				*vector.New(200*r.Float64()-100, 200*r.Float64()-100),
+				vector.Scale(math.Pow(10, 2.5*r.Float64()-1), u()),
+			),
+			tmin,
+			tmax,
+		)
+		radius := math.Pow(10, 2*r.Float64()-1)
+		tau := math.Pow(10, 4*r.Float64()-2)
+
+		// Collisions occur for roughly a third of the inputs.
+		l := tmax - tmin
+		p := vector.Add(
+			s.L().L(tmin+l*(2*r.Float64()-0.5)),
+			vector.Scale(3*radius*r.Float64(), u()),
+		)
+		q := s.L().L(tmin + l*(2*r.Float64()-0.5))
+		v := vector.Add(
+			vector.Scale(math.Pow(10, 2*r.Float64()-1)/tau, vector.Sub(q, p)),
+			vector.Scale(radius/tau*math.Pow(10, 2*r.Float64()-1), u()),
+		)
+		return config{
+			segment: s,
+			agent:   *agent.New(agent.O{P: p, V: v, R: radius}),
+			tau:     tau,
+		}
+	}
+
+	// ds is listed in a fixed order to ensure the test names are
+	// reproducible between runs.
+	ds := []domain.D{
+		domain.CollisionLeft,
+		domain.CollisionRight,
+		domain.CollisionLine,
+		domain.Left,
+		domain.LeftCircle,
+		domain.Right,
+		domain.RightCircle,
+		domain.Line,
+	}
+
+	r := rand.New(rand.NewSource(0))
+	ts, err := conformance.Sample(r, conformance.O[config, domain.D]{
+		G:       g,
+		D:       func(c config) domain.D { return New(c.segment, 0, c.agent, c.tau).domain() },
+		Domains: ds,
+		N:       n,
+	})
+	if err != nil {
+		t.Fatalf("Sample() returned an unexpected error: %v", err)
+	}
+	for i, c := range ts.Panics {
+		c.name = fmt.Sprintf("Random/Panic/%v", i)
+		t.Errorf("domain() panicked for input:\n%v", literal(c))
+	}
+	for _, d := range ds {
+		for i, c := range ts.T[d] {
+			c.name = fmt.Sprintf("Random/Domain=%v/%v", d, i)
+			testConfigs = append(testConfigs, c)
+		}
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got, want, ok := check(c); !ok {
+				if flipped(got, want, c.agent.V()) {
+					t.Skipf("known divergence from the reference: ORCA() = %v, want = %v", got, want)
+				}
+				m := decode(conformance.Minimize(encode(c), func(fs []float64) bool {
+					c := decode(fs)
+					if c.agent.R() <= 0 || c.tau < minTau || c.segment.TMin() != 0 || c.segment.TMax() <= 0 || vector.Within(c.segment.L().D(), *vector.New(0, 0)) {
+						return false
+					}
+					_, _, ok := check(c)
+					return !ok
+				}))
+				m.name = "Minimized"
+				t.Errorf("ORCA() = %v, want = %v; minimized input:\n%v", got, want, literal(m))
+			}
+		})
+	}
+}
@@ -15,9 +15,19 @@ import (
 
 type VO struct {
 	obstacle segment.S
+
+	// r is the radius of the obstacle, i.e. half its thickness.
+	r float64
+
+	// robust indicates the VO should classify the collision domains with
+	// exact geometric predicates.
+	robust bool
 }
 
-func New(obstacle segment.S) *VO {
+// New constructs a VO for a wall with the input radius, i.e. the wall is the
+// capsule of points within r of the obstacle segment. A zero-width wall may be
+// constructed by setting r = 0.
+func New(obstacle segment.S, r float64) *VO {
 	if !obstacle.Feasible() {
 		panic(
 			fmt.Sprintf(
@@ -27,7 +37,26 @@ func New(obstacle segment.S) *VO {
 		)
 	}
 
-	return &VO{obstacle: obstacle}
+	if r < 0 {
+		panic(fmt.Sprintf("cannot construct VO object, wall radius %v is negative", r))
+	}
+
+	return &VO{obstacle: obstacle, r: r}
 }
 
-func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP { return cache.New(vo.obstacle, a, tau).ORCA() }
+// NewRobust constructs a wall VO which uses exact geometric predicates to
+// classify the agent position relative to the wall, and which never panics for
+// agents which lie (nearly) on the boundary of the wall. See cache.NewRobust for
+// more details.
+func NewRobust(obstacle segment.S, r float64) *VO {
+	vo := New(obstacle, r)
+	vo.robust = true
+	return vo
+}
+
+func (vo VO) ORCA(a agent.A, tau float64) hyperplane.HP {
+	if vo.robust {
+		return cache.NewRobust(vo.obstacle, vo.r, a, tau).ORCA()
+	}
+	return cache.New(vo.obstacle, vo.r, a, tau).ORCA()
+}
@@ -79,8 +79,16 @@ func TestConformance(t *testing.T) {
 
 	for _, c := range testConfigs {
 		t.Run(c.name, func(t *testing.T) {
-			a := New(c.obstacle)
+			a := New(c.obstacle, 0)
 			b := wall.New(c.obstacle)
+
+			// The reference orients the ORCA plane towards the
+			// agent velocity, and therefore flips the plane when
+			// the velocity lies within the VO; the VO under test
+			// orients the plane away from the VO.
+			if got, want := a.ORCA(c.agent, c.tau), b.ORCA(c.agent, c.tau); vector.WithinEpsilon(got.N(), vector.Scale(-1, want.N()), epsilon.Relative(0.05)) && !got.In(c.agent.V()) {
+				t.Skipf("known divergence from the reference: ORCA() = %v, want = %v", got, want)
+			}
 			t.Run(fmt.Sprintf("%v/ORCA/N", c.name), func(t *testing.T) {
 				got := a.ORCA(c.agent, c.tau).N()
 				want := b.ORCA(c.agent, c.tau).N()
@@ -102,3 +110,65 @@ func TestConformance(t *testing.T) {
 		})
 	}
 }
+
+// TestThickness checks that the VO of a thick wall matches the VO of a
+// zero-width wall, where the agent radius is enlarged by the wall radius.
+func TestThickness(t *testing.T) {
+	const n = 1000
+
+	type config struct {
+		name     string
+		obstacle segment.S
+		agent    agentimpl.A
+		w        float64
+		tau      float64
+	}
+
+	testConfigs := []config{
+		{
+			name: "Simple",
+			obstacle: *segment.New(
+				*line.New(*vector.New(-2, 2), *vector.New(1, 0)),
+				0,
+				4,
+			),
+			agent: *agentimpl.New(agentimpl.O{
+				P: *vector.New(0, 0),
+				V: *vector.New(0, 4),
+				R: 1,
+			}),
+			w:   0.5,
+			tau: 1,
+		},
+	}
+	for i := 0; i < n; i++ {
+		testConfigs = append(testConfigs, config{
+			name:     fmt.Sprintf("Random-%v", i),
+			obstacle: rs(),
+			agent: *agentimpl.New(agentimpl.O{
+				P: rv(),
+				V: rv(),
+				R: rn() + 100,
+			}),
+			w:   rn() + 100,
+			tau: rn() + 101,
+		})
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got := New(c.obstacle, c.w).ORCA(c.agent, c.tau)
+			want := New(c.obstacle, 0).ORCA(
+				*agentimpl.New(agentimpl.O{
+					P: c.agent.P(),
+					V: c.agent.V(),
+					R: c.agent.R() + c.w,
+				}),
+				c.tau,
+			)
+			if !hyperplane.Within(got, want) {
+				t.Errorf("ORCA() = %v, want = %v", got, want)
+			}
+		})
+	}
+}
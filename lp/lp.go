@@ -0,0 +1,266 @@
+// Package lp is a general-purpose incremental 2D linear programming solver.
+//
+// The solver is the same one used internally by go-orca to find the optimal
+// agent velocity given a set of ORCA half-planes, and is an implementation of
+// Algorithm 2DBoundedLP from de Berg et al. (2008), with two relaxations --
+//
+//  1. the bounding constraint M may be non-linear, e.g. a maximum speed
+//     circle, and
+//  2. the objective function O may be any function which has a single optimum
+//     over a line segment, e.g. the distance to a target point.
+//
+// Given a set of half-planes in the form HP(p, n), where the feasible region of
+// each half-plane faces into the normal n, Solve finds the point within the
+// bounding constraint which satisfies all half-planes and is optimal with
+// respect to the objective function.
+//
+// Unlike the internal solver, this package never panics on bad input, and
+// instead returns a grpc status error, e.g.
+//
+//	v, err := lp.Solve(m, cs, lp.Maximize(c), m.V(c))
+//	if status.Code(err) == codes.FailedPrecondition {
+//		// The system is infeasible.
+//	}
+//
+// See Relax for a solver which returns a best-effort solution to infeasible
+// systems.
+package lp
+
+import (
+	"math"
+
+	"github.com/downflux/go-geometry/2d/constraint"
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/internal/solver/bounds/circular"
+	"github.com/downflux/go-orca/internal/solver/bounds/ellipse"
+	"github.com/downflux/go-orca/internal/solver/bounds/intersection"
+	"github.com/downflux/go-orca/internal/solver/bounds/polygon"
+	"github.com/downflux/go-orca/internal/solver/bounds/unbounded"
+	"github.com/downflux/go-orca/internal/solver/feasibility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	c2d "github.com/downflux/go-orca/internal/geometry/2d/constraint"
+	s2d "github.com/downflux/go-orca/internal/solver/2d"
+	s3d "github.com/downflux/go-orca/internal/solver/3d"
+)
+
+// O is an objective function for the LP. Given a feasible line segment, O
+// returns the optimal point on the segment.
+//
+// O must have a single optimum over any segment; the incremental solver relies
+// on the fact that the optimum of the relaxed problem always lies on the most
+// recently added constraint. O may return a point at infinity (i.e. with
+// infinite or NaN coordinates) if the objective is unbounded along the
+// segment.
+type O func(s segment.S) vector.V
+
+// M is a bounding constraint for the solution, e.g. a maximum speed circle.
+//
+// See internal/solver/2d.M for more details on how bounding constraints are
+// used by the solver.
+type M interface {
+	// Bound returns the segment of intersection between the bounding
+	// constraint and the characteristic line of the input constraint. If
+	// M is unbounded, the returned segment may be (half-)infinite.
+	//
+	// Bound must return false if the line lies outside M.
+	Bound(c constraint.C) (segment.S, bool)
+
+	// In checks if the input vector lies within M.
+	In(v vector.V) bool
+
+	// V maps the input direction to a point on the boundary of M. This is
+	// used to seed the slack-variable fallback in Relax.
+	V(v vector.V) vector.V
+
+	// Clamp returns the point within M which is closest to the input
+	// vector.
+	Clamp(v vector.V) vector.V
+}
+
+// Unbounded returns a bounding constraint which admits all of ℝ².
+func Unbounded() M { return unbounded.M{} }
+
+// Circular returns a bounding constraint which admits all vectors with a
+// magnitude of at most r. Circular returns an error if r is negative or not
+// finite; use Unbounded for a bounding constraint which admits all of ℝ².
+func Circular(r float64) (M, error) {
+	if math.IsNaN(r) || math.IsInf(r, 0) || r < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid circular bound radius %v", r)
+	}
+	return *circular.New(r), nil
+}
+
+// Polygon returns a bounding constraint which admits all vectors within the
+// convex polygon with the input vertices. Polygon returns an error if the
+// polygon is degenerate or not convex.
+func Polygon(vs []vector.V) (M, error) {
+	m, err := polygon.New(vs)
+	if err != nil {
+		return nil, err
+	}
+	return *m, nil
+}
+
+// Ellipse returns a bounding constraint which admits all vectors within the
+// ellipse centered at c, with semi-axis a along the direction h and semi-axis b
+// perpendicular to h.
+func Ellipse(c vector.V, a float64, b float64, h vector.V) (M, error) {
+	m, err := ellipse.New(c, a, b, h)
+	if err != nil {
+		return nil, err
+	}
+	return *m, nil
+}
+
+// Intersection returns a bounding constraint which admits all vectors within
+// both input bounding constraints. Intersection returns an error if the two
+// bounds are disjoint.
+func Intersection(a M, b M) (M, error) {
+	m, err := intersection.New(a, b)
+	if err != nil {
+		return nil, err
+	}
+	return *m, nil
+}
+
+// Distance returns an objective function which minimizes the distance to the
+// input target vector.
+func Distance(v vector.V) O {
+	return func(s segment.S) vector.V { return s.L().L(s.T(v)) }
+}
+
+// Maximize returns a linear objective function which maximizes the dot product
+// of the solution with the input cost vector c.
+//
+// Ties, i.e. when the segment is perpendicular to c, are broken by choosing
+// the point on the segment closest to the origin.
+func Maximize(c vector.V) O {
+	return func(s segment.S) vector.V {
+		d := vector.Dot(s.L().D(), c)
+		switch {
+		case epsilon.Within(d, 0):
+			return s.L().L(s.T(*vector.New(0, 0)))
+		case d > 0:
+			return s.L().L(s.TMax())
+		default:
+			return s.L().L(s.TMin())
+		}
+	}
+}
+
+// Minimize returns a linear objective function which minimizes the dot product
+// of the solution with the input cost vector c.
+func Minimize(c vector.V) O { return Maximize(vector.Scale(-1, c)) }
+
+// Solve finds the point within the bounding constraint m which satisfies all
+// input half-planes and is optimal with respect to the objective function o.
+//
+// The input vector v is the initial solution, and must be the optimum of o over
+// m alone, e.g. for a Maximize(c) objective over a Circular bound, v should be
+// m.V(c). For Distance objectives, m.Clamp of the target vector is
+// appropriate.
+//
+// N.B.: A linear objective has no finite optimum over an Unbounded bound, so
+// linear objectives should instead be paired with a (large) Circular bound.
+//
+// Solve returns an error with code
+//
+//   - InvalidArgument if any half-plane has a zero normal;
+//   - OutOfRange if v lies outside m, or if the problem is unbounded; and
+//   - FailedPrecondition if no point satisfies all half-planes.
+func Solve(m M, cs []hyperplane.HP, o O, v vector.V) (vector.V, error) {
+	ds, err := constraints(cs)
+	if err != nil {
+		return vector.V{}, err
+	}
+	if !finite(v) || !m.In(v) {
+		return vector.V{}, status.Errorf(codes.OutOfRange, "initial solution %v lies outside the bounding constraint", v)
+	}
+
+	u, f := s2d.Solve(m, ds, s2d.O(o), v)
+	switch f {
+	case feasibility.Infeasible:
+		return vector.V{}, status.Errorf(codes.OutOfRange, "initial solution %v lies outside the bounding constraint", v)
+	case feasibility.Partial:
+		return vector.V{}, status.Errorf(codes.FailedPrecondition, "cannot find a solution which satisfies all constraints")
+	}
+	if !finite(u) {
+		return vector.V{}, status.Errorf(codes.OutOfRange, "the objective function is unbounded over the feasible region")
+	}
+	return u, nil
+}
+
+// Relax finds the point within the bounding constraint m which is closest to
+// the input target vector v and which satisfies all input half-planes.
+//
+// If no such point exists, Relax falls back to the slack-variable solver used
+// by go-orca for infeasible ORCA systems, which relaxes the half-planes in an
+// attempt to minimize the penetration distance into their infeasible regions,
+// as in the official RVO2 implementation. Relax therefore only returns an
+// error on invalid input.
+//
+// The fallback requires m to be bounded, as it seeds its search from the
+// boundary of m. Relax returns an error with code
+//
+//   - InvalidArgument if any half-plane has a zero normal, if v is not finite,
+//     or if the fallback is required and m is unbounded; and
+//   - Internal if the fallback fails to find a solution, e.g. if a custom
+//     bounding constraint does not satisfy the M interface contract.
+func Relax(m M, cs []hyperplane.HP, v vector.V) (u vector.V, err error) {
+	ds, err := constraints(cs)
+	if err != nil {
+		return vector.V{}, err
+	}
+	if !finite(v) {
+		return vector.V{}, status.Errorf(codes.InvalidArgument, "invalid target vector %v", v)
+	}
+
+	v = m.Clamp(v)
+
+	u, f := s2d.Solve(m, ds, s2d.O(Distance(v)), v)
+	if f == feasibility.Partial {
+		if _, ok := m.(unbounded.M); ok {
+			return vector.V{}, status.Errorf(codes.InvalidArgument, "cannot relax the constraints over an unbounded constraint")
+		}
+
+		// The fallback may panic if m is a custom bounding
+		// constraint which cannot be mapped to its boundary.
+		defer func() {
+			if r := recover(); r != nil {
+				u, err = vector.V{}, status.Errorf(codes.Internal, "cannot find a relaxed solution for the given constraints: %v", r)
+			}
+		}()
+		u, f = s3d.Solve(m, ds, u)
+	}
+	if f != feasibility.Feasible || !finite(u) {
+		return vector.V{}, status.Errorf(codes.Internal, "cannot find a relaxed solution for the given constraints")
+	}
+	return u, nil
+}
+
+// constraints converts the input half-planes into the (relaxable) constraints
+// used by the internal solver.
+func constraints(cs []hyperplane.HP) ([]c2d.C, error) {
+	ds := make([]c2d.C, 0, len(cs))
+	for _, c := range cs {
+		if !finite(c.P()) || !finite(c.N()) || vector.Within(c.N(), *vector.New(0, 0)) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid half-plane %v", c)
+		}
+		ds = append(ds, *c2d.New(constraint.C(c), true))
+	}
+	return ds, nil
+}
+
+func finite(v vector.V) bool {
+	for _, x := range []float64{v.X(), v.Y()} {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
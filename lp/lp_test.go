@@ -0,0 +1,249 @@
+package lp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/hyperplane"
+	"github.com/downflux/go-geometry/2d/vector"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// circle returns a valid circular bounding constraint of radius r.
+func circle(r float64) M {
+	m, err := Circular(r)
+	if err != nil {
+		panic(fmt.Sprintf("cannot construct circular bound: %v", err))
+	}
+	return m
+}
+
+// panics is a bounding constraint which cannot map vectors to its boundary.
+type panics struct{ M }
+
+func (panics) V(v vector.V) vector.V { panic("cannot map vector to the boundary") }
+
+func TestCircular(t *testing.T) {
+	type config struct {
+		name string
+		r    float64
+		code codes.Code
+	}
+
+	testConfigs := []config{
+		{name: "Zero", r: 0, code: codes.OK},
+		{name: "Positive", r: 1, code: codes.OK},
+		{name: "Negative", r: -1, code: codes.InvalidArgument},
+		{name: "Infinite", r: math.Inf(1), code: codes.InvalidArgument},
+		{name: "NaN", r: math.NaN(), code: codes.InvalidArgument},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := Circular(c.r); status.Code(err) != c.code {
+				t.Errorf("Circular() returned error code %v, want = %v", status.Code(err), c.code)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// box is the unit square [-1, 1] x [-1, 1].
+	box := []hyperplane.HP{
+		*hyperplane.New(*vector.New(-1, 0), *vector.New(1, 0)),
+		*hyperplane.New(*vector.New(1, 0), *vector.New(-1, 0)),
+		*hyperplane.New(*vector.New(0, -1), *vector.New(0, 1)),
+		*hyperplane.New(*vector.New(0, 1), *vector.New(0, -1)),
+	}
+
+	type config struct {
+		name string
+		m    M
+		cs   []hyperplane.HP
+		o    O
+		v    vector.V
+		want vector.V
+		code codes.Code
+	}
+
+	testConfigs := []config{
+		{
+			name: "Maximize/Box",
+			m:    circle(10),
+			cs:   box,
+			o:    Maximize(*vector.New(1, 2)),
+			v:    circle(10).V(*vector.New(1, 2)),
+			want: *vector.New(1, 1),
+			code: codes.OK,
+		},
+		{
+			name: "Minimize/Box",
+			m:    circle(10),
+			cs:   box,
+			o:    Minimize(*vector.New(1, 2)),
+			v:    circle(10).V(*vector.New(-1, -2)),
+			want: *vector.New(-1, -1),
+			code: codes.OK,
+		},
+		{
+			name: "Maximize/Bounded",
+			m:    circle(1),
+			cs:   nil,
+			o:    Maximize(*vector.New(0, 1)),
+			v:    circle(1).V(*vector.New(0, 1)),
+			want: *vector.New(0, 1),
+			code: codes.OK,
+		},
+		// The edge of the box is perpendicular to the cost vector.
+		{
+			name: "Maximize/Tie",
+			m:    circle(10),
+			cs:   box,
+			o:    Maximize(*vector.New(1, 0)),
+			v:    circle(10).V(*vector.New(1, 0)),
+			want: *vector.New(1, 0),
+			code: codes.OK,
+		},
+		{
+			name: "Distance",
+			m:    Unbounded(),
+			cs:   box,
+			o:    Distance(*vector.New(3, 0.5)),
+			v:    *vector.New(3, 0.5),
+			want: *vector.New(1, 0.5),
+			code: codes.OK,
+		},
+		{
+			name: "Infeasible",
+			m:    circle(10),
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vector.New(1, 0), *vector.New(1, 0)),
+				*hyperplane.New(*vector.New(-1, 0), *vector.New(-1, 0)),
+			},
+			o:    Distance(*vector.New(0, 0)),
+			v:    *vector.New(0, 0),
+			code: codes.FailedPrecondition,
+		},
+		{
+			name: "Unbounded",
+			m:    Unbounded(),
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vector.New(0, 1), *vector.New(0, -1)),
+			},
+			o:    Maximize(*vector.New(1, 1)),
+			v:    *vector.New(0, 2),
+			code: codes.OutOfRange,
+		},
+		{
+			name: "Invalid/Initial",
+			m:    circle(1),
+			cs:   box,
+			o:    Distance(*vector.New(0, 0)),
+			v:    *vector.New(2, 0),
+			code: codes.OutOfRange,
+		},
+		{
+			name: "Invalid/Constraint",
+			m:    circle(1),
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vector.New(0, 0), *vector.New(0, 0)),
+			},
+			o:    Distance(*vector.New(0, 0)),
+			v:    *vector.New(0, 0),
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Solve(c.m, c.cs, c.o, c.v)
+			if code := status.Code(err); code != c.code {
+				t.Fatalf("Solve() returned error code %v, want = %v", code, c.code)
+			}
+			if err == nil && !vector.Within(got, c.want) {
+				t.Errorf("Solve() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRelax(t *testing.T) {
+	type config struct {
+		name string
+		m    M
+		cs   []hyperplane.HP
+		v    vector.V
+		want vector.V
+		code codes.Code
+	}
+
+	testConfigs := []config{
+		{
+			name: "Feasible",
+			m:    circle(2),
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vector.New(0, 1), *vector.New(0, 1)),
+			},
+			v:    *vector.New(0, 0),
+			want: *vector.New(0, 1),
+			code: codes.OK,
+		},
+		// The two half-planes do not overlap. The slack-variable
+		// fallback minimizes the maximum penetration distance into
+		// the half-planes, i.e. the solution lies on the line x = 0;
+		// as in RVO2, the solution is an extreme point of the bounding
+		// constraint along this line.
+		{
+			name: "Infeasible",
+			m:    circle(2),
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vector.New(1, 0), *vector.New(1, 0)),
+				*hyperplane.New(*vector.New(-1, 0), *vector.New(-1, 0)),
+			},
+			v:    *vector.New(0, 0),
+			want: *vector.New(0, 2),
+			code: codes.OK,
+		},
+		{
+			name: "Unbounded",
+			m:    Unbounded(),
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vector.New(1, 0), *vector.New(1, 0)),
+				*hyperplane.New(*vector.New(-1, 0), *vector.New(-1, 0)),
+			},
+			v:    *vector.New(0, 0),
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Panic",
+			m:    panics{M: circle(2)},
+			cs: []hyperplane.HP{
+				*hyperplane.New(*vector.New(1, 0), *vector.New(1, 0)),
+				*hyperplane.New(*vector.New(-1, 0), *vector.New(-1, 0)),
+			},
+			v:    *vector.New(0, 0),
+			code: codes.Internal,
+		},
+		{
+			name: "Invalid",
+			m:    circle(2),
+			cs:   nil,
+			v:    *vector.New(math.NaN(), 0),
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Relax(c.m, c.cs, c.v)
+			if code := status.Code(err); code != c.code {
+				t.Fatalf("Relax() returned error code %v, want = %v", code, c.code)
+			}
+			if err == nil && !vector.Within(got, c.want) {
+				t.Errorf("Relax() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// Package obstacle defines static circular obstacles, e.g. pillars or trees.
+//
+// Unlike agents, obstacles do not move and do not react to other agents --
+// agents therefore take full responsibility for avoiding obstacles. Obstacles
+// are indexed separately from agents, and are passed into the ORCA step via
+// orca.O.
+package obstacle
+
+import (
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+type O interface {
+	// P returns the center of the obstacle.
+	P() vector.V
+
+	// R returns the radius of the obstacle.
+	R() float64
+}
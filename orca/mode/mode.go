@@ -0,0 +1,35 @@
+// Package mode defines the velocity obstacle construction modes which may be
+// used for agent-agent interactions.
+package mode
+
+type M int
+
+const (
+	// ORCA constructs the reciprocal truncated VO per van den Berg et al.
+	// (2011), where each agent takes half the responsibility of avoiding
+	// the collision.
+	ORCA M = iota
+
+	// HRVO constructs the hybrid reciprocal VO per Snape et al. (2011),
+	// where the apex of the VO is shifted towards the side the agent is
+	// currently passing on, as determined by the relative velocity of the
+	// agents. This breaks the symmetry which causes two agents heading
+	// directly at one another to choose mirror-image velocities (i.e. the
+	// "reciprocal dance").
+	HRVO
+
+	// HRVOPreferred constructs the hybrid reciprocal VO, but chooses the
+	// passing side from the preferred velocities of the agents instead.
+	HRVOPreferred
+)
+
+func (m M) String() string {
+	if s, ok := map[M]string{
+		ORCA:          "ORCA",
+		HRVO:          "HRVO",
+		HRVOPreferred: "HRVO_PREFERRED",
+	}[m]; ok {
+		return s
+	}
+	return "UNKNOWN"
+}
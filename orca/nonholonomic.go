@@ -0,0 +1,45 @@
+package orca
+
+import (
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/footprint"
+	"github.com/downflux/go-orca/agent/nonholonomic"
+)
+
+// inflate returns an agent whose effective radius (and footprint, if set) is
+// enlarged by the tracking error of the input agent. Holonomic agents are
+// returned unmodified.
+func inflate(a agent.A) agent.A {
+	b, ok := a.(nonholonomic.A)
+	if !ok || b.E() == 0 {
+		return a
+	}
+
+	r := inflated{A: a, r: a.R() + b.E()}
+	if f, ok := a.(footprint.A); ok {
+		return inflatedf{
+			inflated: r,
+			f:        *footprint.New(f.F().V(), f.F().R()+b.E()),
+		}
+	}
+	return r
+}
+
+type inflated struct {
+	agent.A
+	r float64
+}
+
+func (a inflated) R() float64 { return a.r }
+
+// Unwrap returns the underlying (uninflated) agent. This exposes the optional
+// interfaces of the underlying agent which are not forwarded by the wrapper,
+// e.g. acceleration.A; see acceleration.Unwrap.
+func (a inflated) Unwrap() agent.A { return a.A }
+
+type inflatedf struct {
+	inflated
+	f footprint.F
+}
+
+func (a inflatedf) F() footprint.F { return a.f }
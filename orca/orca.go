@@ -10,21 +10,42 @@ import (
 	"github.com/downflux/go-kd/kd"
 	"github.com/downflux/go-kd/point"
 	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/acceleration"
+	"github.com/downflux/go-orca/agent/bounds"
+	"github.com/downflux/go-orca/agent/footprint"
+	"github.com/downflux/go-orca/agent/nonholonomic"
 	"github.com/downflux/go-orca/internal/geometry/2d/constraint"
 	"github.com/downflux/go-orca/internal/solver"
+	"github.com/downflux/go-orca/internal/solver/bounds/circular"
+	"github.com/downflux/go-orca/internal/solver/bounds/intersection"
+	"github.com/downflux/go-orca/internal/solver/bounds/lens"
 	"github.com/downflux/go-orca/internal/vo/agent/opt"
+	"github.com/downflux/go-orca/internal/vo/avo"
+	"github.com/downflux/go-orca/internal/vo/hrvo"
 	"github.com/downflux/go-orca/internal/vo/wall"
+	"github.com/downflux/go-orca/obstacle"
+	"github.com/downflux/go-orca/orca/mode"
+	"github.com/downflux/go-orca/orca/vopt"
 	"github.com/downflux/go-orca/region"
+	"github.com/downflux/go-orca/vo"
 
 	c2d "github.com/downflux/go-geometry/2d/constraint"
+	h2d "github.com/downflux/go-geometry/2d/hypersphere"
 	v2d "github.com/downflux/go-geometry/2d/vector"
 	voagent "github.com/downflux/go-orca/internal/vo/agent"
+	voarc "github.com/downflux/go-orca/internal/vo/arc"
+	vofootprint "github.com/downflux/go-orca/internal/vo/footprint"
+	voobstacle "github.com/downflux/go-orca/internal/vo/obstacle"
 )
 
 // Mutation pairs an agent with a velocity change calculated by ORCA.
 type Mutation struct {
 	A agent.A
 	V v2d.V
+
+	// C is the command which tracks the holonomic velocity V, and is only
+	// set if the agent implements nonholonomic.A.
+	C nonholonomic.C
 }
 
 type P interface {
@@ -32,6 +53,12 @@ type P interface {
 	A() agent.A
 }
 
+// Q is a point in the obstacle K-D tree.
+type Q interface {
+	point.P
+	O() obstacle.O
+}
+
 func agents[T P](ps []T) []agent.A {
 	agents := make([]agent.A, 0, len(ps))
 	for _, p := range ps {
@@ -62,8 +89,92 @@ type O[T P] struct {
 	// of cores on the system for fastest processing times.
 	PoolSize int
 
-	// R is a list of map regions.
+	// R is a list of map regions. Regions may consist of any number of
+	// connected segments, e.g. the outline of a building; each segment
+	// generates a separate constraint.
 	R []region.R
+
+	// C is an optional K-D tree containing all static circular obstacles.
+	// Agents take full responsibility for avoiding obstacles.
+	C *kd.KD[Q]
+
+	// Mode determines how the velocity obstacles between (circular) agents
+	// are constructed. By default, the reciprocal ORCA construction is
+	// used.
+	//
+	// N.B.: Agents which both implement footprint.A always use the
+	// footprint-aware ORCA VO. Step returns an error if two such agents
+	// interact under an HRVO mode.
+	Mode mode.M
+
+	// VOpt chooses the optimization velocity of each agent, given its
+	// neighbors. The agent-agent ORCA half-planes are constructed from
+	// the relative optimization velocity of the pair and anchored at the
+	// optimization velocity of the agent; by default, the current agent
+	// velocity is used. See vopt for caveats on reciprocity.
+	//
+	// N.B.: VOpt is ignored in the HRVO construction modes.
+	VOpt vopt.P
+
+	// Shuffle randomizes the order in which the constraints of each agent
+	// are processed by the LP solver, which guarantees an expected
+	// linear-time solve for agents with many neighbors.
+	Shuffle bool
+
+	// Bias is the passing-side preference of constrained agents, as a
+	// fraction of the preferred agent speed. Positive values encourage
+	// agents to pass each other on the right, and negative values on the
+	// left. This helps break symmetric deadlocks, e.g. when two agents
+	// try to move directly past each other.
+	Bias float64
+
+	// Jitter adds random noise to the solved agent velocities, as a
+	// fraction of the length of the binding constraint segment.
+	Jitter float64
+
+	// Seed seeds the constraint shuffle and jitter; Step is deterministic
+	// for a given seed and tick.
+	//
+	// Each agent derives its own seed from Seed, the index of the agent,
+	// and Tick, so that agents with symmetric constraints do not draw
+	// identical shuffles and jitter, and an agent does not draw the same
+	// jitter every tick.
+	Seed int64
+
+	// Tick is the index of the current simulation tick; see Seed.
+	Tick int64
+
+	// Tier is an optional function which returns the priority tier of the
+	// constraint generated for the agent a by its neighbor b, e.g. to
+	// ensure a unit will squeeze past allied units before it pushes into
+	// enemy units. When the constraints of an agent are infeasible, the
+	// solver relaxes constraints in lower tiers first. By default, all
+	// agent-agent constraints are in tier 0.
+	//
+	// Walls and static obstacles are never relaxed.
+	Tier func(a agent.A, b agent.A) int
+
+	// Warm seeds the LP solver of each agent with the current agent
+	// velocity, i.e. the solution of the previous simulation tick, which
+	// speeds up the solver when the set of constraints binding the agent
+	// velocity is stable from tick to tick. This does not change the
+	// solution for agents with feasible constraints, unless Jitter is set.
+	//
+	// N.B.: The LP solve is typically a small fraction of the cost of a
+	// tick, so the warm start does not noticeably speed up a tick.
+	Warm bool
+
+	// Robust uses exact geometric predicates when classifying agents
+	// relative to walls, and when intersecting constraints in the LP
+	// solver. Near-degenerate configurations, e.g. agents just touching a
+	// wall, or nearly parallel constraints, are then classified
+	// consistently, at a small performance cost.
+	Robust bool
+}
+
+type job struct {
+	i int
+	a agent.A
 }
 
 type result struct {
@@ -71,6 +182,18 @@ type result struct {
 	err error
 }
 
+// seed derives the solver seed of the i-th agent in tick t from the input
+// simulation seed s, by mixing the inputs with the SplitMix64 finalizer.
+func seed(s int64, i int, t int64) int64 {
+	mix := func(z uint64) uint64 {
+		z += 0x9e3779b97f4a7c15
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+	return int64(mix(mix(mix(uint64(s))^uint64(i)) ^ uint64(t)))
+}
+
 func RadialFilter[T P](t *kd.KD[T], c hypersphere.C, f func(p P) bool) []T {
 	offset := vector.M(make([]float64, c.P().Dimension()))
 	for i := vector.D(0); i < c.P().Dimension(); i++ {
@@ -86,8 +209,54 @@ func RadialFilter[T P](t *kd.KD[T], c hypersphere.C, f func(p P) bool) []T {
 	})
 }
 
-// step calculates the ORCA velocity for a single agent.
-func step[T P](a agent.A, t *kd.KD[T], rs []region.R, f func(a agent.A) bool, tau float64) (Mutation, error) {
+// radius returns the maximum radius of all obstacles in the input K-D tree.
+func radius(t *kd.KD[Q]) float64 {
+	if t == nil {
+		return 0
+	}
+	var r float64
+	for _, q := range kd.Data(t) {
+		r = math.Max(r, q.O().R())
+	}
+	return r
+}
+
+// obstacles returns all obstacles which overlap the input circle. Here, r is
+// the maximum radius of all obstacles in the K-D tree.
+func obstacles(t *kd.KD[Q], c hypersphere.C, r float64) []Q {
+	if t == nil {
+		return nil
+	}
+
+	// The obstacle centers lie within c.R() + r of the circle center.
+	r += c.R()
+	offset := vector.M(make([]float64, c.P().Dimension()))
+	for i := vector.D(0); i < c.P().Dimension(); i++ {
+		offset.SetX(i, r)
+	}
+	return kd.RangeSearch(
+		t,
+		*hyperrectangle.New(
+			vector.Sub(c.P(), offset.V()),
+			vector.Add(c.P(), offset.V()),
+		),
+		func(q Q) bool {
+			d := c.R() + q.O().R()
+			return vector.SquaredMagnitude(vector.Sub(q.P(), c.P())) <= d*d
+		},
+	)
+}
+
+// step calculates the ORCA velocity for a single agent. Here, r is the maximum
+// radius of the obstacles in o.C, and i is the index of the agent, which is used
+// to derive the solver seed.
+func step[T P](a agent.A, o O[T], r float64, i int) (Mutation, error) {
+	t, rs, f, tau, m, g := o.T, o.R, o.F, o.Tau, o.Mode, o.VOpt
+
+	// Non-holonomic agents are enlarged by their tracking error when
+	// constructing VOs.
+	b := inflate(a)
+
 	ps := RadialFilter(
 		t,
 		// N.B.: RVO2 passes in a global state for this
@@ -96,7 +265,7 @@ func step[T P](a agent.A, t *kd.KD[T], rs []region.R, f func(a agent.A) bool, ta
 		// for more information.
 		*hypersphere.New(
 			vector.V(a.P()),
-			tau*a.S()+2*a.R(),
+			tau*a.S()+2*b.R(),
 		),
 		// TODO(minkezhang): Check for interface equality
 		// instead of coordinate equality, via adding an
@@ -109,55 +278,232 @@ func step[T P](a agent.A, t *kd.KD[T], rs []region.R, f func(a agent.A) bool, ta
 		},
 	)
 
+	// Acceleration-constrained agents need to adjust each VO to account for
+	// the time taken to reach the new velocity.
+	_, accel := a.(acceleration.A)
+
+	u := vopt.Current
+	if g != nil {
+		u = g(a, agents(ps))
+	}
+
 	cs := make([]constraint.C, 0, len(ps))
+	// Each segment of a region generates its own ORCA constraint.
 	for _, r := range rs {
-		// TODO(minkezhang): Support multi-segment region ORCA.
-		if len(r.R()) != 1 {
-			panic("UnimplementedError: cannot construct ORCA line for a region not of cardinality 1")
+		// w is the radius of the walls, i.e. half the wall
+		// thickness.
+		var w float64
+		if r, ok := r.(region.W); ok {
+			w = r.W() / 2
 		}
-		// TODO(minkezhang): Add to immutable constraints instead, as
-		// lines are immovable.
+
+		vs := make([]vo.VO, 0, len(r.R()))
+		for _, s := range r.R() {
+			if o.Robust {
+				vs = append(vs, wall.NewRobust(s, w))
+			} else {
+				vs = append(vs, wall.New(s, w))
+			}
+		}
+		if r, ok := r.(region.A); ok {
+			for _, c := range r.A() {
+				vs = append(vs, voarc.New(c, w))
+			}
+		}
+
+		for _, v := range vs {
+			if accel {
+				v = avo.New(v, *v2d.New(0, 0))
+			}
+
+			// TODO(minkezhang): Add to immutable constraints
+			// instead, as lines are immovable.
+			cs = append(
+				cs,
+				*constraint.New(
+					c2d.C(v.ORCA(b, tau)),
+					false,
+				),
+			)
+		}
+	}
+
+	for _, q := range obstacles(o.C, *hypersphere.New(vector.V(a.P()), tau*a.S()+b.R()), r) {
+		var v vo.VO = voobstacle.New(q.O())
+		if accel {
+			v = avo.New(v, *v2d.New(0, 0))
+		}
+
 		cs = append(
 			cs,
 			*constraint.New(
-				c2d.C(wall.New(r.R()[0]).ORCA(a, tau)),
+				c2d.C(v.ORCA(b, tau)),
 				false,
 			),
 		)
 	}
 
+	tier := o.Tier
 	for _, p := range ps {
+		o := opt.O{
+			Weight: opt.WeightEqual,
+			VOpt:   opt.VOpt(u),
+		}
+		q := inflate(p.A())
+
+		var v vo.VO
+		switch m {
+		case mode.ORCA:
+			v = voagent.New(q, o)
+		case mode.HRVO:
+			v = hrvo.New(q, hrvo.O{})
+		case mode.HRVOPreferred:
+			v = hrvo.New(q, hrvo.O{Preferred: true})
+		default:
+			return Mutation{}, fmt.Errorf("invalid VO construction mode %v", m)
+		}
+
+		// Footprint-aware VOs are only constructed when both agents
+		// declare a footprint, and take precedence over the circular
+		// ORCA VO. The footprint VO has no hybrid reciprocal variant,
+		// so footprint agents cannot interact in the HRVO modes.
+		_, i := b.(footprint.A)
+		_, j := q.(footprint.A)
+		if i && j {
+			if m != mode.ORCA {
+				return Mutation{}, fmt.Errorf("cannot construct a %v velocity obstacle between two agents with footprints", m)
+			}
+			v = vofootprint.New(q, o)
+		}
+
+		if accel {
+			v = avo.New(v, q.V())
+		}
+
+		var t int
+		if tier != nil {
+			t = tier(a, p.A())
+		}
+
 		cs = append(
 			cs,
-			*constraint.New(
-				c2d.C(
-					voagent.New(
-						p.A(),
-						opt.O{
-							Weight: opt.WeightEqual,
-							VOpt:   opt.VOptV,
-						},
-					).ORCA(a, tau),
-				),
-				true,
+			*constraint.NewTier(
+				c2d.C(v.ORCA(b, tau)),
+				t,
 			),
 		)
 	}
 
+	// Find a new velocity for an agent which minimizes the difference to
+	// the velocity a.T() which satisifies all constraints.
+	//
+	// This optimization velocity may be adjusted, per van de Berg et al.
+	// (2011), section 5.2; however, setting this velocity to a.V() does
+	// not seem very convincing -- agents tend to stop drifting towards the
+	// target in packed conditions.
+	var w *v2d.V
+	if o.Warm {
+		v := a.V()
+		w = &v
+	}
+
+	l, err := bound(a)
+	if err != nil {
+		return Mutation{}, err
+	}
+
+	v := solver.Solve(l, cs, a.T(), solver.O{
+		Shuffle: o.Shuffle,
+		Bias:    o.Bias,
+		Jitter:  o.Jitter,
+		Seed:    seed(o.Seed, i, o.Tick),
+		Warm:    w,
+		Robust:  o.Robust,
+	})
+
+	var c nonholonomic.C
+	if a, ok := a.(nonholonomic.A); ok {
+		c = a.M().C(a.H(), v)
+	}
+
 	return Mutation{
 		A: a,
-		// Find a new velocity for an agent which minimizes the
-		// difference to the velocity a.T() which satisifies all
-		// constraints.
-		//
-		// This optimization velocity may be adjusted, per van de Berg
-		// et al. (2011), section 5.2; however, setting this velocity to
-		// a.V() does not seem very convincing -- agents tend to stop
-		// drifting towards the target in packed conditions.
-		V: solver.Solve(cs, a.T(), a.S()),
+		V: v,
+		C: c,
 	}, nil
 }
 
+// bound returns the set of velocities reachable by the agent in the next
+// timestep.
+//
+// Non-holonomic agents are additionally bounded by the set of velocities which
+// they may track within their tracking error; see nonholonomic.B.
+func bound(a agent.A) (solver.M, error) {
+	var m solver.M = *circular.New(a.S())
+
+	// general indicates m is not a maximum speed circle centered at the
+	// origin.
+	var general bool
+	if b, ok := a.(bounds.A); ok {
+		m, general = b.B(), true
+	}
+	if b, ok := a.(nonholonomic.A); ok && b.E() > 0 {
+		t, err := nonholonomic.B(b)
+		if err != nil {
+			return nil, fmt.Errorf("cannot construct the trackable velocities of the agent: %w", err)
+		}
+		if general {
+			l, err := intersection.New(m, t)
+			if err != nil {
+				return nil, fmt.Errorf("cannot bound the agent by its trackable velocities: %w", err)
+			}
+			m = *l
+		} else {
+			// The trackable velocities are already bounded by the
+			// maximum speed of the agent.
+			m, general = t, true
+		}
+	}
+
+	b, ok := a.(acceleration.A)
+	if !ok {
+		return m, nil
+	}
+
+	c := *h2d.New(a.V(), b.Accel()*b.DT())
+	if general {
+		// As lens.M only intersects circles, we fall back to the more
+		// general (but slower) intersection.M for arbitrary velocity
+		// bounds.
+		d, _ := lens.New(c, c)
+		l, err := intersection.New(m, *d)
+		if err != nil {
+			// The agent is currently moving outside its velocity
+			// bounds and cannot return within a single timestep.
+			// Unlike the maximum speed circle below, the bounds may
+			// not be exceeded, e.g. a vehicle which may not strafe
+			// sideways; we instead restrict the agent to the point
+			// within the bounds which is closest to the set of
+			// reachable velocities, i.e. closest to the current
+			// velocity.
+			q := *h2d.New(m.Clamp(a.V()), 0)
+			d, _ := lens.New(q, q)
+			return *d, nil
+		}
+		return *l, nil
+	}
+
+	l, err := lens.New(*h2d.New(*v2d.New(0, 0), a.S()), c)
+	if err != nil {
+		// The agent is currently moving faster than its maximum speed
+		// and cannot slow down within a single timestep; we allow the
+		// agent to exceed the maximum speed for now, and instead
+		// only bound the output by the maximum change in velocity.
+		l, _ = lens.New(c, c)
+	}
+	return *l, nil
+}
+
 // Step calculates new velocities for a collection of agents such that they will
 // avoid collitions within the specified input duration tau.
 //
@@ -174,16 +520,17 @@ func Step[T P](o O[T]) ([]Mutation, error) {
 		panic("must specify Step with non-zero pool size")
 	}
 	as := agents(kd.Data(o.T))
+	r := radius(o.C)
 
 	// Ensure channel reads aren't blocking due to dispatch or fold
 	// operation.
-	ach := make(chan agent.A, 8*o.PoolSize)
+	ach := make(chan job, 8*o.PoolSize)
 	rch := make(chan result, 8*o.PoolSize)
 
-	go func(ch chan<- agent.A) {
+	go func(ch chan<- job) {
 		defer close(ch)
-		for _, a := range as {
-			ch <- a
+		for i, a := range as {
+			ch <- job{i: i, a: a}
 		}
 	}(ach)
 
@@ -195,9 +542,9 @@ func Step[T P](o O[T]) ([]Mutation, error) {
 	// Start up a number of workers to find the iterative velocity in
 	// parallel.
 	for i := 0; i < n; i++ {
-		go func(jobs <-chan agent.A, results chan<- result) {
-			for a := range jobs {
-				mutation, err := step(a, o.T, o.R, o.F, o.Tau)
+		go func(jobs <-chan job, results chan<- result) {
+			for j := range jobs {
+				mutation, err := step(j.a, o, r, j.i)
 				results <- result{
 					m:   mutation,
 					err: err,
@@ -11,16 +11,81 @@ import (
 	"github.com/downflux/go-geometry/nd/vector"
 	"github.com/downflux/go-kd/kd"
 	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/agent/acceleration"
+	"github.com/downflux/go-orca/agent/bounds"
+	"github.com/downflux/go-orca/agent/footprint"
+	"github.com/downflux/go-orca/agent/nonholonomic"
+	"github.com/downflux/go-orca/internal/solver/bounds/ellipse"
+	"github.com/downflux/go-orca/lp"
+	"github.com/downflux/go-orca/obstacle"
+	"github.com/downflux/go-orca/orca/mode"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	v2d "github.com/downflux/go-geometry/2d/vector"
 	agentimpl "github.com/downflux/go-orca/internal/agent"
 )
 
 var (
-	_ P = p{}
+	_ P              = p{}
+	_ Q              = q{}
+	_ acceleration.A = accel{}
+	_ bounds.A       = bounded{}
+	_ acceleration.A = boundedaccel{}
+	_ bounds.A       = boundedaccel{}
+	_ nonholonomic.A = nh{}
+	_ footprint.A    = fp{}
 )
 
+// accel is an agent with a maximum acceleration.
+type accel struct {
+	*agentimpl.A
+	Max  float64
+	Tick float64
+}
+
+func (a accel) Accel() float64 { return a.Max }
+func (a accel) DT() float64    { return a.Tick }
+
+// bounded is an agent with a non-circular velocity bound.
+type bounded struct {
+	*agentimpl.A
+	Bound lp.M
+}
+
+func (a bounded) B() lp.M { return a.Bound }
+
+// boundedaccel is an agent with a non-circular velocity bound and a maximum
+// acceleration.
+type boundedaccel struct {
+	bounded
+	Max  float64
+	Tick float64
+}
+
+func (a boundedaccel) Accel() float64 { return a.Max }
+func (a boundedaccel) DT() float64    { return a.Tick }
+
+// fp is an agent with a non-circular footprint.
+type fp struct {
+	*agentimpl.A
+	Footprint footprint.F
+}
+
+func (a fp) F() footprint.F { return a.Footprint }
+
+// nh is a non-holonomic agent.
+type nh struct {
+	*agentimpl.A
+	Heading v2d.V
+	Model   nonholonomic.M
+	Error   float64
+}
+
+func (a nh) H() v2d.V          { return a.Heading }
+func (a nh) M() nonholonomic.M { return a.Model }
+func (a nh) E() float64        { return a.Error }
+
 type p struct {
 	a agent.A
 }
@@ -28,6 +93,14 @@ type p struct {
 func (p p) A() agent.A  { return p.a }
 func (p p) P() vector.V { return vector.V(p.a.P()) }
 
+// q is a static circular obstacle.
+type q struct {
+	c hypersphere.C
+}
+
+func (q q) O() obstacle.O { return q.c }
+func (q q) P() vector.V   { return vector.V(q.c.P()) }
+
 func rn() float64 { return rand.Float64()*200 - 100 }
 func rv() v2d.V   { return *v2d.New(rn(), rn()) }
 func ra() agentimpl.A {
@@ -64,10 +137,11 @@ func rt(n int) *kd.KD[P] {
 
 func TestStep(t *testing.T) {
 	type config struct {
-		name   string
-		agents []agent.A
-		tau    float64
-		f      func(a agent.A) bool
+		name      string
+		agents    []agent.A
+		obstacles []hypersphere.C
+		tau       float64
+		f         func(a agent.A) bool
 
 		want []Mutation
 	}
@@ -96,10 +170,140 @@ func TestStep(t *testing.T) {
 				},
 			}
 		}(),
+		func() config {
+			a := accel{
+				A: agentimpl.New(
+					agentimpl.O{
+						P: *v2d.New(1, 2),
+						V: *v2d.New(0, 0),
+						T: *v2d.New(0, 4),
+						S: 10,
+					},
+				),
+				Max:  1,
+				Tick: 2,
+			}
+
+			return config{
+				name:   "Acceleration",
+				agents: []agent.A{a},
+				tau:    1e-2,
+				f:      func(agent.A) bool { return true },
+				want: []Mutation{
+					Mutation{
+						A: a,
+						// The agent may only change its
+						// velocity by 2 within the
+						// timestep.
+						V: *v2d.New(0, 2),
+					},
+				},
+			}
+		}(),
+		func() config {
+			m, err := bounds.Ellipse(*v2d.New(0, 1), 4, 1, 1)
+			if err != nil {
+				t.Fatalf("Ellipse() = _, %v, want = _, %v", err, nil)
+			}
+			a := bounded{
+				A: agentimpl.New(
+					agentimpl.O{
+						P: *v2d.New(1, 2),
+						V: *v2d.New(0, 0),
+						T: *v2d.New(0, -4),
+						S: 4,
+					},
+				),
+				Bound: m,
+			}
+
+			return config{
+				name:   "Bounds",
+				agents: []agent.A{a},
+				tau:    1e-2,
+				f:      func(agent.A) bool { return true },
+				want: []Mutation{
+					Mutation{
+						A: a,
+						// The agent is facing away from
+						// the target, and may only
+						// reverse slowly.
+						V: *v2d.New(0, -1),
+					},
+				},
+			}
+		}(),
+		func() config {
+			m := nonholonomic.DifferentialDrive{T: 1, W: 10}
+			a := nh{
+				A: agentimpl.New(
+					agentimpl.O{
+						P: *v2d.New(1, 2),
+						V: *v2d.New(0, 0),
+						// The agent may track a
+						// perpendicular velocity with
+						// an error of ||v|| sin(π / 4).
+						T: *v2d.New(0, 1),
+						S: 10,
+					},
+				),
+				Heading: *v2d.New(1, 0),
+				Model:   m,
+				Error:   1,
+			}
+
+			return config{
+				name:   "Nonholonomic",
+				agents: []agent.A{a},
+				tau:    1e-2,
+				f:      func(agent.A) bool { return true },
+				want: []Mutation{
+					Mutation{
+						A: a,
+						V: a.T(),
+						C: m.C(a.H(), a.T()),
+					},
+				},
+			}
+		}(),
+		func() config {
+			a := agentimpl.New(
+				agentimpl.O{
+					P: *v2d.New(0, 0),
+					V: *v2d.New(0, 0),
+					T: *v2d.New(0, 10),
+					R: 1,
+					S: 10,
+				},
+			)
+
+			return config{
+				name:   "Obstacle",
+				agents: []agent.A{a},
+				obstacles: []hypersphere.C{
+					*hypersphere.New(*v2d.New(0, 5), 1),
+				},
+				tau: 1,
+				f:   func(agent.A) bool { return true },
+				want: []Mutation{
+					Mutation{
+						A: a,
+						// The agent may not reach the
+						// obstacle within 𝜏.
+						V: *v2d.New(0, 3),
+					},
+				},
+			}
+		}(),
 	}
 
 	for _, c := range testConfigs {
 		t.Run(c.name, func(t *testing.T) {
+			var qs []Q
+			for _, o := range c.obstacles {
+				qs = append(qs, q{c: o})
+			}
+
 			var ps []P
 			for _, a := range c.agents {
 				ps = append(ps, p{a: a})
@@ -117,7 +321,12 @@ func TestStep(t *testing.T) {
 			}
 
 			got, err := Step(O[P]{
-				T:        tr,
+				T: tr,
+				C: kd.New(kd.O[Q]{
+					Data: qs,
+					K:    2,
+					N:    1,
+				}),
 				Tau:      c.tau,
 				F:        c.f,
 				PoolSize: 1,
@@ -131,7 +340,9 @@ func TestStep(t *testing.T) {
 				cmp.AllowUnexported(
 					agentimpl.A{},
 					hypersphere.C{},
+					ellipse.M{},
 				),
+				cmpopts.EquateApprox(0, 1e-10),
 			); diff != "" {
 				t.Errorf("Step() mismatch (-want +got):\n%v", diff)
 			}
@@ -139,6 +350,177 @@ func TestStep(t *testing.T) {
 	}
 }
 
+// TestBound checks that an acceleration-constrained agent which lies outside its
+// velocity bounds is not allowed to choose a velocity outside the bounds.
+func TestBound(t *testing.T) {
+	b, err := lp.Circular(1)
+	if err != nil {
+		t.Fatalf("Circular() = _, %v, want = _, %v", err, nil)
+	}
+	a := boundedaccel{
+		bounded: bounded{
+			A: agentimpl.New(
+				agentimpl.O{
+					P: *v2d.New(0, 0),
+					V: *v2d.New(5, 0),
+					T: *v2d.New(5, 5),
+					S: 5,
+				},
+			),
+			Bound: b,
+		},
+		Max:  1,
+		Tick: 1,
+	}
+
+	m, err := bound(a)
+	if err != nil {
+		t.Fatalf("bound() encountered an unexpected error: %v", err)
+	}
+
+	// The reachable velocities lie within 1 of (5, 0), and the closest
+	// point within the bounds is (1, 0).
+	want := *v2d.New(1, 0)
+	if got := m.Clamp(a.T()); !v2d.Within(got, want) {
+		t.Errorf("Clamp() = %v, want = %v", got, want)
+	}
+	if !m.In(want) {
+		t.Errorf("In() = false, want = true")
+	}
+}
+
+// TestSeed checks that agents draw distinct solver seeds, both within a tick
+// and across ticks.
+func TestSeed(t *testing.T) {
+	const n = 100
+
+	seen := map[int64]bool{}
+	for i := 0; i < n; i++ {
+		for tick := int64(0); tick < n; tick++ {
+			s := seed(1, i, tick)
+			if seen[s] {
+				t.Fatalf("seed(1, %v, %v) = %v, which was already drawn", i, tick, s)
+			}
+			seen[s] = true
+		}
+	}
+
+	if seed(1, 0, 0) == seed(2, 0, 0) {
+		t.Errorf("seed() does not depend on the simulation seed")
+	}
+}
+
+// TestBias checks that a passing-side bias shifts the velocities of two
+// approaching agents towards the right of their preferred velocities.
+func TestBias(t *testing.T) {
+	a := agentimpl.New(agentimpl.O{
+		P: *v2d.New(-0.3, 0),
+		V: *v2d.New(0, 1),
+		T: *v2d.New(0, 1),
+		R: 1,
+		S: 2,
+	})
+	b := agentimpl.New(agentimpl.O{
+		P: *v2d.New(0, 3),
+		V: *v2d.New(0, -1),
+		T: *v2d.New(0, -1),
+		R: 1,
+		S: 2,
+	})
+
+	tr := kd.New(kd.O[P]{
+		Data: []P{p{a: a}, p{a: b}},
+		K:    2,
+		N:    1,
+	})
+
+	vs := map[agent.A]v2d.V{}
+	for _, bias := range []float64{0, 0.5} {
+		ms, err := Step(O[P]{
+			T:        tr,
+			Tau:      10,
+			F:        func(agent.A) bool { return true },
+			PoolSize: 1,
+			Bias:     bias,
+		})
+		if err != nil {
+			t.Fatalf("Step() = _, %v, want = _, %v", err, nil)
+		}
+
+		for _, m := range ms {
+			if bias == 0 {
+				vs[m.A] = m.V
+				continue
+			}
+
+			// The right of each agent is the direction obtained by
+			// rotating its target velocity clockwise.
+			r := *v2d.New(m.A.T().Y(), -m.A.T().X())
+			if v2d.Dot(v2d.Sub(m.V, vs[m.A]), r) <= 0 {
+				t.Errorf("V() = %v, want a velocity to the right of the unbiased velocity %v", m.V, vs[m.A])
+			}
+		}
+	}
+}
+
+// TestFootprintMode checks that two agents with footprints interact via the
+// footprint-aware ORCA VO, and that the HRVO modes, which have no footprint
+// variant, are rejected instead of silently ignored.
+func TestFootprintMode(t *testing.T) {
+	f := *footprint.New([]v2d.V{*v2d.New(-1, 0), *v2d.New(1, 0)}, 0.5)
+	a := fp{
+		A: agentimpl.New(agentimpl.O{
+			P: *v2d.New(0, 0),
+			V: *v2d.New(1, 0),
+			T: *v2d.New(1, 0),
+			R: 1.5,
+			S: 2,
+		}),
+		Footprint: f,
+	}
+	b := fp{
+		A: agentimpl.New(agentimpl.O{
+			P: *v2d.New(5, 0),
+			V: *v2d.New(-1, 0),
+			T: *v2d.New(-1, 0),
+			R: 1.5,
+			S: 2,
+		}),
+		Footprint: f,
+	}
+
+	type config struct {
+		name    string
+		mode    mode.M
+		success bool
+	}
+
+	testConfigs := []config{
+		{name: "ORCA", mode: mode.ORCA, success: true},
+		{name: "HRVO", mode: mode.HRVO, success: false},
+		{name: "HRVOPreferred", mode: mode.HRVOPreferred, success: false},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Step(O[P]{
+				T: kd.New(kd.O[P]{
+					Data: []P{p{a: a}, p{a: b}},
+					K:    2,
+					N:    1,
+				}),
+				Tau:      10,
+				F:        func(agent.A) bool { return true },
+				PoolSize: 1,
+				Mode:     c.mode,
+			})
+			if success := err == nil; success != c.success {
+				t.Errorf("Step() = _, %v, want success = %v", err, c.success)
+			}
+		})
+	}
+}
+
 func BenchmarkStep(b *testing.B) {
 	type config struct {
 		name string
@@ -177,3 +559,18 @@ func BenchmarkStep(b *testing.B) {
 		})
 	}
 }
+
+func TestInflate(t *testing.T) {
+	a := agentimpl.New(agentimpl.O{R: 1})
+
+	t.Run("Holonomic", func(t *testing.T) {
+		if got := inflate(a).R(); got != 1 {
+			t.Errorf("R() = %v, want = %v", got, 1)
+		}
+	})
+	t.Run("Nonholonomic", func(t *testing.T) {
+		if got := inflate(nh{A: a, Error: 0.5}).R(); got != 1.5 {
+			t.Errorf("R() = %v, want = %v", got, 1.5)
+		}
+	})
+}
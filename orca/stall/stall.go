@@ -0,0 +1,180 @@
+// Package stall detects agents which are unable to make progress towards their
+// targets, e.g. agents deadlocked in a narrow corridor, or agents stuck in the
+// middle of a dense crowd.
+//
+// ORCA is a purely local collision avoidance algorithm, and makes no guarantee
+// that an agent will ever reach its target. A stalled agent will typically
+// settle into a near-zero velocity, even though its preferred velocity A.T() is
+// large. The detector tracks, for each agent, the shortfall of the solved
+// velocity v relative to the preferred velocity t, i.e.
+//
+//	s = 1 - ⟨v, t⟩ / ‖t‖²
+//
+// over a sliding window of simulation steps. An agent moving at its preferred
+// velocity has a shortfall of 0, and a stationary agent has a shortfall of 1.
+// Agents whose mean shortfall over the full window exceeds some threshold are
+// considered stalled.
+//
+// The caller may then e.g. trigger a replanning of the agent path, or demote
+// the priority of the agent constraints via orca.O.Tier.
+package stall
+
+import (
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/orca"
+)
+
+// O is an options struct passed into the stall detector constructor.
+type O struct {
+	// W is the size of the sliding window, in number of steps.
+	W int
+
+	// S is the mean shortfall threshold over the sliding window above
+	// which an agent is considered stalled, e.g. 0.9.
+	S float64
+
+	// R is the maximum gap between the boundaries of two stalled agents
+	// for which the agents are considered part of the same stall cluster.
+	R float64
+}
+
+// h is the shortfall history of a single agent.
+type h struct {
+	a agent.A
+
+	// ss is a ring buffer of the shortfall of the agent over the last W
+	// steps.
+	ss []float64
+	i  int
+	n  int
+
+	// sum is the running sum of ss.
+	sum float64
+}
+
+func (h *h) add(s float64) {
+	if h.n == len(h.ss) {
+		h.sum -= h.ss[h.i]
+	} else {
+		h.n++
+	}
+	h.ss[h.i] = s
+	h.sum += s
+	h.i = (h.i + 1) % len(h.ss)
+}
+
+// D is a stall detector.
+//
+// N.B.: Agents are tracked by identity, and therefore must be comparable, e.g.
+// pointers to agent structs, as is the case for the agents returned by
+// orca.Step.
+type D struct {
+	o O
+
+	hs map[agent.A]*h
+
+	// as is the list of tracked agents, in the order they were first
+	// observed; this ensures the output of the detector is deterministic.
+	as []agent.A
+}
+
+func New(o O) *D {
+	if o.W <= 0 {
+		panic("cannot construct a stall detector with a non-positive window size")
+	}
+	return &D{
+		o:  o,
+		hs: map[agent.A]*h{},
+	}
+}
+
+// Add records the solved velocities of the current simulation step, e.g. as
+// returned by orca.Step. Agents which are not present in the input are no
+// longer tracked.
+//
+// Agents without a preferred velocity, e.g. agents which have arrived at their
+// destination, are never considered stalled, and their history is reset.
+func (d *D) Add(ms []orca.Mutation) {
+	seen := make(map[agent.A]bool, len(ms))
+	for _, m := range ms {
+		seen[m.A] = true
+
+		g, ok := d.hs[m.A]
+		if !ok {
+			g = &h{a: m.A, ss: make([]float64, d.o.W)}
+			d.hs[m.A] = g
+			d.as = append(d.as, m.A)
+		}
+
+		t := m.A.T()
+		if l := vector.SquaredMagnitude(t); epsilon.Within(l, 0) {
+			*g = h{a: m.A, ss: g.ss}
+		} else {
+			g.add(1 - vector.Dot(m.V, t)/l)
+		}
+	}
+
+	as := d.as[:0]
+	for _, a := range d.as {
+		if seen[a] {
+			as = append(as, a)
+		} else {
+			delete(d.hs, a)
+		}
+	}
+	d.as = as
+}
+
+// Stalled returns the list of agents which are currently stalled.
+func (d *D) Stalled() []agent.A {
+	var as []agent.A
+	for _, a := range d.as {
+		if g := d.hs[a]; g.n == d.o.W && g.sum/float64(g.n) > d.o.S {
+			as = append(as, a)
+		}
+	}
+	return as
+}
+
+// Clusters groups the currently stalled agents into clusters of agents which
+// are within O.R of one another, e.g. a group of agents mutually deadlocked in
+// a corridor. Singleton clusters are included in the output.
+func (d *D) Clusters() [][]agent.A {
+	as := d.Stalled()
+
+	// Union-find over the stalled agents.
+	ps := make([]int, len(as))
+	for i := range ps {
+		ps[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if ps[i] != i {
+			ps[i] = find(ps[i])
+		}
+		return ps[i]
+	}
+
+	for i := range as {
+		for j := i + 1; j < len(as); j++ {
+			r := as[i].R() + as[j].R() + d.o.R
+			if vector.SquaredMagnitude(vector.Sub(as[i].P(), as[j].P())) <= r*r {
+				ps[find(i)] = find(j)
+			}
+		}
+	}
+
+	var cs [][]agent.A
+	indices := map[int]int{}
+	for i, a := range as {
+		r := find(i)
+		if _, ok := indices[r]; !ok {
+			indices[r] = len(cs)
+			cs = append(cs, nil)
+		}
+		cs[indices[r]] = append(cs[indices[r]], a)
+	}
+	return cs
+}
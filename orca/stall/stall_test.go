@@ -0,0 +1,122 @@
+package stall
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/orca"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+)
+
+func TestD(t *testing.T) {
+	// a and b are adjacent agents, and c is a distant agent.
+	a := agentimpl.New(agentimpl.O{P: *vector.New(0, 0), T: *vector.New(1, 0), R: 1})
+	b := agentimpl.New(agentimpl.O{P: *vector.New(2.5, 0), T: *vector.New(-1, 0), R: 1})
+	c := agentimpl.New(agentimpl.O{P: *vector.New(100, 0), T: *vector.New(0, 1), R: 1})
+	// g has arrived at its destination.
+	g := agentimpl.New(agentimpl.O{P: *vector.New(200, 0), R: 1})
+
+	type config struct {
+		name string
+
+		// ms is the list of solved velocities for each step.
+		ms [][]orca.Mutation
+
+		stalled  []agent.A
+		clusters [][]agent.A
+	}
+
+	stop := func(as ...agent.A) []orca.Mutation {
+		var ms []orca.Mutation
+		for _, a := range as {
+			ms = append(ms, orca.Mutation{A: a, V: *vector.New(0, 0)})
+		}
+		return ms
+	}
+
+	testConfigs := []config{
+		{
+			name:    "Moving",
+			ms:      [][]orca.Mutation{{{A: a, V: a.T()}}, {{A: a, V: a.T()}}, {{A: a, V: a.T()}}},
+			stalled: nil,
+		},
+		{
+			name:    "PartialWindow",
+			ms:      [][]orca.Mutation{stop(a), stop(a)},
+			stalled: nil,
+		},
+		{
+			name:     "Stalled",
+			ms:       [][]orca.Mutation{stop(a), stop(a), stop(a)},
+			stalled:  []agent.A{a},
+			clusters: [][]agent.A{{a}},
+		},
+		{
+			name: "Recovered",
+			ms: [][]orca.Mutation{
+				stop(a), stop(a), stop(a),
+				{{A: a, V: a.T()}}, {{A: a, V: a.T()}},
+			},
+			stalled: nil,
+		},
+		{
+			name:    "Arrived",
+			ms:      [][]orca.Mutation{stop(g), stop(g), stop(g)},
+			stalled: nil,
+		},
+		{
+			name:    "Removed",
+			ms:      [][]orca.Mutation{stop(a, c), stop(a, c), stop(a, c), stop(c)},
+			stalled: []agent.A{c},
+			clusters: [][]agent.A{
+				{c},
+			},
+		},
+		{
+			name:    "Clusters",
+			ms:      [][]orca.Mutation{stop(a, b, c), stop(a, b, c), stop(a, b, c)},
+			stalled: []agent.A{a, b, c},
+			clusters: [][]agent.A{
+				{a, b},
+				{c},
+			},
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			d := New(O{W: 3, S: 0.9, R: 1})
+			for _, ms := range c.ms {
+				d.Add(ms)
+			}
+
+			if got := d.Stalled(); !equal(got, c.stalled) {
+				t.Errorf("Stalled() = %v, want = %v", got, c.stalled)
+			}
+
+			got := d.Clusters()
+			if len(got) != len(c.clusters) {
+				t.Fatalf("Clusters() = %v, want = %v", got, c.clusters)
+			}
+			for i := range got {
+				if !equal(got[i], c.clusters[i]) {
+					t.Errorf("Clusters()[%v] = %v, want = %v", i, got[i], c.clusters[i])
+				}
+			}
+		})
+	}
+}
+
+func equal(as []agent.A, bs []agent.A) bool {
+	if len(as) != len(bs) {
+		return false
+	}
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
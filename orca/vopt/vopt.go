@@ -0,0 +1,69 @@
+// Package vopt defines policies which choose the optimization velocity of an
+// agent during a simulation step.
+//
+// Per van den Berg et al. (2011), the ORCA half-plane induced by an obstacle is
+// anchored at the optimization velocity of the agent. The choice of
+// optimization velocity trades off between two failure modes --
+//
+//  1. anchoring at the current velocity allows agents to keep their current
+//     heading in sparse conditions, but tends to make agents stall in dense
+//     crowds, as the feasible region shrinks around the (possibly blocked)
+//     current velocity; and
+//  2. anchoring at the zero vector guarantees the linear program is always
+//     feasible, but forces agents to be overly conservative when there is
+//     plenty of room to maneuver.
+//
+// Anchoring at the preferred velocity is a middle ground, and is suitable for
+// sparse areas, where agents are mostly moving towards their targets.
+//
+// N.B.: The ORCA constraints of a pair of agents are only reciprocal, and
+// therefore only carry the collision avoidance guarantee, if both agents use
+// the same optimization velocity convention. Constant policies always satisfy
+// this. Policies which depend on the neighbors of the agent, e.g. Density, may
+// choose different conventions for the two agents in a pair, in which case the
+// guarantee does not hold for that pair.
+package vopt
+
+import (
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+	"github.com/downflux/go-orca/internal/vo/agent/opt"
+)
+
+// V returns the optimization velocity of the input agent.
+type V func(a agent.A) vector.V
+
+var (
+	// Current anchors the ORCA half-plane at the current agent velocity.
+	Current V = V(opt.VOptV)
+
+	// Zero anchors the ORCA half-plane at the zero vector.
+	Zero V = V(opt.VOptZero)
+
+	// Target anchors the ORCA half-plane at the preferred agent velocity.
+	Target V = V(opt.VOptT)
+)
+
+// P is a policy which chooses the optimization velocity of an agent, given the
+// agent and its neighbors for the current simulation step.
+type P func(a agent.A, ns []agent.A) V
+
+// Constant returns a policy which always chooses the input optimization
+// velocity.
+func Constant(v V) P { return func(agent.A, []agent.A) V { return v } }
+
+// Density returns a policy which chooses the zero vector when the agent has at
+// least n neighbors, i.e. the agent is in a dense crowd, and chooses the
+// preferred velocity otherwise.
+//
+// N.B.: Density is not reciprocal; an agent at the edge of a crowd may choose
+// the preferred velocity while its neighbor inside the crowd chooses the zero
+// vector.
+func Density(n int) P {
+	return func(a agent.A, ns []agent.A) V {
+		if len(ns) >= n {
+			return Zero
+		}
+		return Target
+	}
+}
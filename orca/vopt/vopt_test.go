@@ -0,0 +1,56 @@
+package vopt
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-orca/agent"
+
+	agentimpl "github.com/downflux/go-orca/internal/agent"
+)
+
+func TestP(t *testing.T) {
+	a := *agentimpl.New(agentimpl.O{
+		P: *vector.New(0, 0),
+		V: *vector.New(1, 2),
+		T: *vector.New(3, 4),
+		R: 1,
+		S: 10,
+	})
+
+	type config struct {
+		name string
+		p    P
+		ns   []agent.A
+		want vector.V
+	}
+
+	testConfigs := []config{
+		{
+			name: "Constant/Current",
+			p:    Constant(Current),
+			ns:   []agent.A{a, a},
+			want: *vector.New(1, 2),
+		},
+		{
+			name: "Density/Sparse",
+			p:    Density(2),
+			ns:   []agent.A{a},
+			want: *vector.New(3, 4),
+		},
+		{
+			name: "Density/Dense",
+			p:    Density(2),
+			ns:   []agent.A{a, a},
+			want: *vector.New(0, 0),
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.p(a, c.ns)(a); !vector.Within(got, c.want) {
+				t.Errorf("P()() = %v, want = %v", got, c.want)
+			}
+		})
+	}
+}
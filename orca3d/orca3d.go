@@ -0,0 +1,161 @@
+// Package orca3d calculates collision-free velocities for spherical agents in
+// 3D ambient space, e.g. aerial agents.
+//
+// The API mirrors the 2D orca package; here, the K-D tree must be constructed
+// with K = 3.
+package orca3d
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/downflux/go-geometry/nd/hyperplane"
+	"github.com/downflux/go-geometry/nd/hyperrectangle"
+	"github.com/downflux/go-geometry/nd/hypersphere"
+	"github.com/downflux/go-geometry/nd/vector"
+	"github.com/downflux/go-kd/kd"
+	"github.com/downflux/go-kd/point"
+	"github.com/downflux/go-orca/agent/3d"
+	"github.com/downflux/go-orca/internal/3d/solver"
+
+	v3d "github.com/downflux/go-geometry/3d/vector"
+	voagent "github.com/downflux/go-orca/internal/3d/vo/agent"
+)
+
+// Mutation pairs an agent with a velocity change calculated by ORCA.
+type Mutation struct {
+	A agent.A
+	V v3d.V
+}
+
+type P interface {
+	point.P
+	A() agent.A
+}
+
+func agents[T P](ps []T) []agent.A {
+	agents := make([]agent.A, 0, len(ps))
+	for _, p := range ps {
+		agents = append(agents, p.A())
+	}
+
+	return agents
+}
+
+// O is an options struct passed into the Step function.
+type O[T P] struct {
+	// T is a 3D K-D tree containing all agents.
+	T *kd.KD[T]
+
+	// Tau is the lookahead time -- Step will avoid agent velocities which
+	// will lead to collisions within this time frame.
+	Tau float64
+
+	// F is a function which is used during neighbor searching to filter out
+	// agents for which collisions are allowed.
+	F func(a agent.A) bool
+
+	// PoolSize is the number of workers that will process the the agents in
+	// parallel.
+	PoolSize int
+}
+
+type result struct {
+	m   Mutation
+	err error
+}
+
+func RadialFilter[T P](t *kd.KD[T], c hypersphere.C, f func(p P) bool) []T {
+	offset := vector.M(make([]float64, c.P().Dimension()))
+	for i := vector.D(0); i < c.P().Dimension(); i++ {
+		offset.SetX(i, c.R())
+	}
+
+	r := *hyperrectangle.New(
+		vector.Sub(c.P(), offset.V()),
+		vector.Add(c.P(), offset.V()),
+	)
+	return kd.RangeSearch(t, r, func(p T) bool {
+		return vector.SquaredMagnitude(vector.Sub(p.P(), c.P())) <= c.R()*c.R() && f(p)
+	})
+}
+
+// step calculates the ORCA velocity for a single agent.
+func step[T P](a agent.A, t *kd.KD[T], f func(a agent.A) bool, tau float64) (Mutation, error) {
+	ps := RadialFilter(
+		t,
+		*hypersphere.New(
+			vector.V(a.P()),
+			tau*a.S()+2*a.R(),
+		),
+		func(p P) bool {
+			return !vector.Within(p.P(), vector.V(a.P())) && f(p.(P).A())
+		},
+	)
+
+	cs := make([]hyperplane.HP, 0, len(ps))
+	for _, p := range ps {
+		cs = append(cs, voagent.New(p.A()).ORCA(a, tau))
+	}
+
+	return Mutation{
+		A: a,
+		V: solver.Solve(cs, a.T(), a.S()),
+	}, nil
+}
+
+// Step calculates new velocities for a collection of agents such that they will
+// avoid collitions within the specified input duration tau.
+//
+// Step parallelizes ORCA calculations. Note that while calling Step, the input
+// K-D tree and agents must not be mutated.
+func Step[T P](o O[T]) ([]Mutation, error) {
+	if o.PoolSize == 0 {
+		panic("must specify Step with non-zero pool size")
+	}
+	as := agents(kd.Data(o.T))
+
+	ach := make(chan agent.A, 8*o.PoolSize)
+	rch := make(chan result, 8*o.PoolSize)
+
+	go func(ch chan<- agent.A) {
+		defer close(ch)
+		for _, a := range as {
+			ch <- a
+		}
+	}(ach)
+
+	n := int(
+		math.Min(
+			float64(len(as)),
+			float64(o.PoolSize)),
+	)
+	for i := 0; i < n; i++ {
+		go func(jobs <-chan agent.A, results chan<- result) {
+			for a := range jobs {
+				mutation, err := step(a, o.T, o.F, o.Tau)
+				results <- result{
+					m:   mutation,
+					err: err,
+				}
+			}
+		}(ach, rch)
+	}
+
+	mutations := make([]Mutation, 0, len(as))
+	var errors []error
+
+	for i := 0; i < len(as); i++ {
+		r := <-rch
+		if r.err != nil {
+			errors = append(errors, r.err)
+		} else {
+			mutations = append(mutations, r.m)
+		}
+	}
+
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("could not generate ORCA simulation: %v", errors)
+	}
+	return mutations, nil
+}
@@ -0,0 +1,105 @@
+package orca3d
+
+import (
+	"testing"
+
+	"github.com/downflux/go-geometry/nd/vector"
+	"github.com/downflux/go-kd/kd"
+	"github.com/downflux/go-orca/agent/3d"
+
+	v3d "github.com/downflux/go-geometry/3d/vector"
+	agentimpl "github.com/downflux/go-orca/internal/3d/agent"
+)
+
+var (
+	_ P = &p{}
+)
+
+type p struct {
+	a *a
+}
+
+func (p *p) A() agent.A  { return p.a }
+func (p *p) P() vector.V { return vector.V(p.a.P()) }
+
+// a is a mutable agent used for simulating multiple steps.
+type a struct {
+	agentimpl.A
+	p v3d.V
+	v v3d.V
+}
+
+func (a *a) P() v3d.V { return a.p }
+func (a *a) V() v3d.V { return a.v }
+
+func TestStep(t *testing.T) {
+	t.Run("Single", func(t *testing.T) {
+		x := &a{
+			A: *agentimpl.New(agentimpl.O{
+				R: 1,
+				S: 10,
+				T: *v3d.New(1, 2, 3),
+			}),
+			p: *v3d.New(1, 2, 3),
+			v: *v3d.New(0, 0, 0),
+		}
+		got, err := Step(O[*p]{
+			T:        kd.New(kd.O[*p]{Data: []*p{&p{a: x}}, K: 3, N: 1}),
+			Tau:      1,
+			F:        func(agent.A) bool { return true },
+			PoolSize: 1,
+		})
+		if err != nil {
+			t.Fatalf("Step() = _, %v, want = _, %v", err, nil)
+		}
+		if len(got) != 1 || !v3d.Within(got[0].V, x.T()) {
+			t.Errorf("Step() = %v, want = %v", got, x.T())
+		}
+	})
+
+	// Two agents moving directly towards each other in 3D space should
+	// not collide.
+	t.Run("HeadOn", func(t *testing.T) {
+		const dt = 0.1
+
+		ps := []*p{
+			&p{a: &a{
+				A: *agentimpl.New(agentimpl.O{R: 1, S: 2, T: *v3d.New(2, 0, 0)}),
+				p: *v3d.New(-10, 0, 0),
+				v: *v3d.New(2, 0, 0),
+			}},
+			&p{a: &a{
+				A: *agentimpl.New(agentimpl.O{R: 1, S: 2, T: *v3d.New(-2, 0, 0)}),
+				p: *v3d.New(10, 0, 0.1),
+				v: *v3d.New(-2, 0, 0),
+			}},
+		}
+
+		for i := 0; i < 200; i++ {
+			ms, err := Step(O[*p]{
+				T:        kd.New(kd.O[*p]{Data: ps, K: 3, N: 1}),
+				Tau:      2,
+				F:        func(agent.A) bool { return true },
+				PoolSize: 2,
+			})
+			if err != nil {
+				t.Fatalf("Step() = _, %v, want = _, %v", err, nil)
+			}
+			for _, m := range ms {
+				b := m.A.(*a)
+				b.v = m.V
+			}
+			for _, q := range ps {
+				q.a.p = v3d.Add(q.a.p, v3d.Scale(dt, q.a.v))
+			}
+
+			if d := v3d.Magnitude(v3d.Sub(ps[0].a.p, ps[1].a.p)); d < 2-1e-2 {
+				t.Fatalf("agents collided at step %v: distance = %v", i, d)
+			}
+		}
+
+		if ps[0].a.p.X() < 10 || ps[1].a.p.X() > -10 {
+			t.Errorf("agents did not pass each other: %v, %v", ps[0].a.p, ps[1].a.p)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Package arc defines a circular arc, i.e. a curved analogue of a line segment,
+// which may be used to represent curved walls, e.g. round buildings or curved
+// roads.
+package arc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/downflux/go-geometry/2d/vector"
+)
+
+// A is a circular arc, centered at C() with radius R(), which spans the polar
+// angles [TMin(), TMax()] in the counter-clockwise direction.
+type A struct {
+	c vector.V
+	r float64
+
+	tmin float64
+	tmax float64
+}
+
+// New constructs an arc from the input circle and polar angle range (in
+// radians). The arc spans counter-clockwise from tmin to tmax, and must not
+// span more than a full circle.
+func New(c vector.V, r float64, tmin float64, tmax float64) *A {
+	if r <= 0 {
+		panic(fmt.Sprintf("cannot construct an arc with non-positive radius %v", r))
+	}
+	if tmax <= tmin || tmax-tmin > 2*math.Pi {
+		panic(fmt.Sprintf("cannot construct an arc with invalid angle range [%v, %v]", tmin, tmax))
+	}
+	return &A{
+		c:    c,
+		r:    r,
+		tmin: tmin,
+		tmax: tmax,
+	}
+}
+
+func (a A) C() vector.V   { return a.c }
+func (a A) R() float64    { return a.r }
+func (a A) TMin() float64 { return a.tmin }
+func (a A) TMax() float64 { return a.tmax }
+
+// L returns the point on the arc circle at the input polar angle.
+func (a A) L(t float64) vector.V {
+	return vector.Add(a.c, *vector.New(a.r*math.Cos(t), a.r*math.Sin(t)))
+}
+
+// T returns the polar angle of the point on the arc closest to the input
+// vector.
+func (a A) T(v vector.V) float64 {
+	w := vector.Sub(v, a.c)
+
+	// The center of the circle is equidistant to all points on the arc.
+	if vector.Within(w, *vector.New(0, 0)) {
+		return a.tmin
+	}
+
+	// Normalize the polar angle of v into [tmin, tmin + 2π).
+	t := math.Atan2(w.Y(), w.X())
+	t = a.tmin + math.Mod(math.Mod(t-a.tmin, 2*math.Pi)+2*math.Pi, 2*math.Pi)
+	if t <= a.tmax {
+		return t
+	}
+
+	// v lies outside the angular span of the arc, and the closest point
+	// is one of the two ends.
+	if vector.SquaredMagnitude(vector.Sub(v, a.L(a.tmin))) <= vector.SquaredMagnitude(vector.Sub(v, a.L(a.tmax))) {
+		return a.tmin
+	}
+	return a.tmax
+}
+
+// Distance returns the distance between the input vector and the arc.
+func (a A) Distance(v vector.V) float64 {
+	return vector.Magnitude(vector.Sub(v, a.L(a.T(v))))
+}
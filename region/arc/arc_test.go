@@ -0,0 +1,48 @@
+package arc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/downflux/go-geometry/2d/vector"
+	"github.com/downflux/go-geometry/epsilon"
+)
+
+func TestT(t *testing.T) {
+	// a is the upper half of the unit circle.
+	a := *New(*vector.New(0, 0), 1, 0, math.Pi)
+
+	type config struct {
+		name string
+		a    A
+		v    vector.V
+		want float64
+		d    float64
+	}
+
+	testConfigs := []config{
+		{name: "Interior", a: a, v: *vector.New(0, 2), want: math.Pi / 2, d: 1},
+		{name: "Interior/Inside", a: a, v: *vector.New(0, 0.5), want: math.Pi / 2, d: 0.5},
+		{name: "End/Min", a: a, v: *vector.New(2, -1), want: 0, d: math.Sqrt2},
+		{name: "End/Max", a: a, v: *vector.New(-2, -1), want: math.Pi, d: math.Sqrt2},
+		{name: "Center", a: a, v: *vector.New(0, 0), want: 0, d: 1},
+		{
+			name: "Wrap",
+			a:    *New(*vector.New(0, 0), 1, 3*math.Pi/2, 5*math.Pi/2),
+			v:    *vector.New(2, 0),
+			want: 2 * math.Pi,
+			d:    1,
+		},
+	}
+
+	for _, c := range testConfigs {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.a.T(c.v); !epsilon.Within(got, c.want) {
+				t.Errorf("T() = %v, want = %v", got, c.want)
+			}
+			if got := c.a.Distance(c.v); !epsilon.Within(got, c.d) {
+				t.Errorf("Distance() = %v, want = %v", got, c.d)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package region
 
 import (
 	"github.com/downflux/go-geometry/2d/segment"
+	"github.com/downflux/go-orca/region/arc"
 )
 
 // R is a collection of line segments representing physical walls within the
@@ -9,3 +10,31 @@ import (
 type R interface {
 	R() []segment.S
 }
+
+// W is an optional extension of the region interface for walls with a non-zero
+// thickness.
+//
+// By default, walls are zero-width line segments. A thick wall is instead the
+// capsule of points within W() / 2 of each line segment, which allows e.g. a
+// corridor wall to be represented by a single segment, rather than a pair of
+// segments which leave gaps at the ends.
+type W interface {
+	R
+
+	// W returns the thickness of each of the walls in the region, i.e.
+	// twice the radius of the capsule around each wall.
+	W() float64
+}
+
+// A is an optional extension of the region interface for regions with curved
+// walls.
+//
+// Each arc generates a single ORCA constraint, which allows e.g. round buildings
+// to be represented exactly, rather than approximated by many short segments.
+// As with segments, arcs are thickened by W(), if set.
+type A interface {
+	R
+
+	// A returns the curved walls of the region.
+	A() []arc.A
+}